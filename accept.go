@@ -0,0 +1,87 @@
+package echoi18n
+
+import "golang.org/x/text/language"
+
+// acceptMatcher matches Accept-Language headers against the supported languages.
+type acceptMatcher struct {
+	tags      []language.Tag             // Supported languages.
+	supported map[string]bool            // Supported languages by tag, matched without parsing.
+	scripts   map[string]language.Script // Preferred scripts of bare languages.
+	matcher   language.Matcher           // Matcher of the supported languages.
+}
+
+// newAcceptMatcher creates a matcher of the supported languages, with the
+// preferred scripts of bare languages keyed by language. Invalid scripts are
+// ignored.
+func newAcceptMatcher(tags []language.Tag, scripts map[string]string) *acceptMatcher {
+	supported := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		supported[tag.String()] = true
+	}
+	m := &acceptMatcher{tags: tags, supported: supported, matcher: language.NewMatcher(tags)}
+	for lang, name := range scripts {
+		script, err := language.ParseScript(name)
+		if err != nil {
+			continue
+		}
+		if m.scripts == nil {
+			m.scripts = make(map[string]language.Script, len(scripts))
+		}
+		m.scripts[lang] = script
+	}
+	return m
+}
+
+// match returns the supported language best matching an Accept-Language
+// header, weighing its tags by their RFC 7231 quality values, or false if
+// none matches. A header of a single supported tag is matched without
+// allocating.
+func (m *acceptMatcher) match(header string) (string, bool) {
+	if m.supported[header] {
+		return header, true
+	}
+	tags, _, err := language.ParseAcceptLanguage(header)
+	if err != nil || len(tags) == 0 {
+		return "", false
+	}
+	for i, tag := range tags {
+		tags[i] = m.withScript(tag)
+	}
+	_, index, confidence := m.matcher.Match(tags...)
+	if confidence == language.No {
+		return "", false
+	}
+	return m.tags[index].String(), true
+}
+
+// withScript adds the preferred script of a bare language tag, e.g.
+// "zh-Hant" for "zh". Tags with a script or region are returned as is.
+func (m *acceptMatcher) withScript(tag language.Tag) language.Tag {
+	if len(m.scripts) == 0 {
+		return tag
+	}
+	base, script, region := tag.Raw()
+	if script != (language.Script{}) || region != (language.Region{}) {
+		return tag
+	}
+	preferred, ok := m.scripts[base.String()]
+	if !ok {
+		return tag
+	}
+	if composed, err := language.Compose(base, preferred); err == nil {
+		return composed
+	}
+	return tag
+}
+
+// defaultAcceptMatcher matches the default AcceptLanguages outside of the
+// middleware.
+var defaultAcceptMatcher = newAcceptMatcher([]language.Tag{language.Chinese, language.English}, nil)
+
+// acceptMatcher returns the matcher of the AcceptLanguages.
+func (c *Config) acceptMatcher() *acceptMatcher {
+	if c.accept != nil {
+		return c.accept
+	}
+	return newAcceptMatcher(c.AcceptLanguages, c.PreferredScripts)
+}
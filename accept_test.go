@@ -0,0 +1,115 @@
+package echoi18n
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// Test_acceptMatcher_match tests matching Accept-Language headers with quality values.
+func Test_acceptMatcher_match(t *testing.T) {
+	t.Parallel()
+	matcher := newAcceptMatcher([]language.Tag{language.English, language.French, language.Chinese}, nil)
+
+	tests := []struct {
+		name   string
+		header string
+		want   string
+		wantOK bool
+	}{
+		{"supported tag", "fr", "fr", true},
+		{"regional tag", "en-US,en;q=0.9,fr;q=0.8", "en", true},
+		{"quality order", "fr;q=0.5,zh;q=0.9", "zh", true},
+		{"zero quality", "fr;q=0,ja", "", false},
+		{"regional fallback", "fr-CA", "fr", true},
+		{"unsupported", "ja,ko;q=0.5", "", false},
+		{"malformed", ";;q=x", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := matcher.match(tt.header)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// TestConfig_negotiate_region tests negotiating the closest supported
+// language of region-qualified tags.
+func TestConfig_negotiate_region(t *testing.T) {
+	t.Parallel()
+	app := echo.New()
+	app.Use(NewMiddleware(&Config{
+		AcceptLanguages: []language.Tag{language.English, language.Chinese, language.Portuguese, language.SimplifiedChinese},
+		Loader:          LoaderFunc(func(path string) ([]byte, error) { return nil, nil }),
+		DebugHeaders:    true,
+	}))
+	app.GET("/", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	tests := []struct {
+		name       string
+		lang       string
+		wantLang   string
+		wantSource string
+	}{
+		{"supported", "pt", "pt", SourceQuery},
+		{"region", "en-GB", "en", SourceQuery},
+		{"base language", "pt-BR", "pt", SourceQuery},
+		{"script", "zh-Hans-SG", "zh-Hans", SourceQuery},
+		{"traditional script", "zh-TW", "zh", SourceQuery},
+		{"unsupported", "ja-JP", "en", SourceFallback},
+		{"malformed", "e!", "en", SourceFallback},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/?lang="+tt.lang, nil)
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+			assert.Equal(t, tt.wantLang, rec.Header().Get(HeaderLanguage))
+			assert.Equal(t, tt.wantSource, rec.Header().Get(HeaderSource))
+		})
+	}
+}
+
+// Test_acceptMatcher_scripts tests matching bare languages with their
+// preferred scripts.
+func Test_acceptMatcher_scripts(t *testing.T) {
+	t.Parallel()
+	tags := []language.Tag{
+		language.English,
+		language.SimplifiedChinese,
+		language.TraditionalChinese,
+		language.Make("sr-Cyrl"),
+		language.Make("sr-Latn"),
+	}
+	plain := newAcceptMatcher(tags, nil)
+	preferred := newAcceptMatcher(tags, map[string]string{"zh": "Hant", "sr": "Latn", "en": "invalid"})
+
+	tests := []struct {
+		name    string
+		matcher *acceptMatcher
+		header  string
+		want    string
+	}{
+		{"likely script", plain, "zh", "zh-Hans"},
+		{"preferred script", preferred, "zh", "zh-Hant"},
+		{"preferred script in list", preferred, "sr, en;q=0.5", "sr-Latn"},
+		{"region kept", preferred, "zh-CN", "zh-Hans"},
+		{"explicit script kept", preferred, "sr-Cyrl-RS", "sr-Cyrl"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.matcher.match(tt.header)
+			assert.True(t, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+	assert.NotContains(t, preferred.scripts, "en")
+}
@@ -0,0 +1,108 @@
+package echoi18n
+
+import (
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"golang.org/x/text/language"
+	"golang.org/x/text/language/display"
+)
+
+// Address is a postal address. Country is a CLDR region code such as "DE".
+type Address struct {
+	Name         string
+	Organization string
+	Street       string
+	City         string
+	Region       string
+	PostalCode   string
+	Country      string
+}
+
+// addressFormats are the address layouts of destination countries, where %N
+// is the name, %O the organization, %A the street, %C the city, %S the region
+// and %Z the postal code. Countries listed in bigEndianAddresses start with the
+// largest unit.
+var addressFormats = map[string]string{
+	"US": "%N\n%O\n%A\n%C, %S %Z",
+	"CA": "%N\n%O\n%A\n%C %S %Z",
+	"AU": "%N\n%O\n%A\n%C %S %Z",
+	"GB": "%N\n%O\n%A\n%C\n%Z",
+	"DE": "%N\n%O\n%A\n%Z %C",
+	"FR": "%N\n%O\n%A\n%Z %C",
+	"ES": "%N\n%O\n%A\n%Z %C %S",
+	"IT": "%N\n%O\n%A\n%Z %C %S",
+	"NL": "%N\n%O\n%A\n%Z %C",
+	"BR": "%O\n%N\n%A\n%C-%S\n%Z",
+	"CN": "%Z\n%S%C\n%A\n%O\n%N",
+	"JP": "〒%Z\n%S%C\n%A\n%O\n%N",
+	"KR": "%S %C\n%A\n%O\n%N\n%Z",
+}
+
+// bigEndianAddresses are the countries whose addresses start with the country.
+var bigEndianAddresses = map[string]bool{"CN": true, "JP": true, "KR": true}
+
+// defaultAddressFormat is used for countries without a specific layout.
+const defaultAddressFormat = "%N\n%O\n%A\n%C %S %Z"
+
+// FormatAddress formats an address for the negotiated language. See
+// Config.FormatAddress.
+func FormatAddress(c echo.Context, addr Address) string {
+	appCfg, err := getConfig(c)
+	if err != nil {
+		return (&Config{}).FormatAddress(language.Und, addr)
+	}
+	return appCfg.FormatAddress(language.Make(appCfg.language(c)), addr)
+}
+
+// FormatAddress formats an address following the conventions of its
+// destination country. The country name is written in lang and omitted for
+// domestic addresses. It can be used outside requests, e.g. in emails.
+func (c *Config) FormatAddress(lang language.Tag, addr Address) string {
+	country := strings.ToUpper(addr.Country)
+	layout, ok := addressFormats[country]
+	if !ok {
+		layout = defaultAddressFormat
+	}
+	formatted := strings.NewReplacer(
+		"%N", addr.Name,
+		"%O", addr.Organization,
+		"%A", addr.Street,
+		"%C", addr.City,
+		"%S", addr.Region,
+		"%Z", addr.PostalCode,
+	).Replace(layout)
+
+	var lines []string
+	for _, line := range strings.Split(formatted, "\n") {
+		line = strings.Trim(strings.Join(strings.Fields(line), " "), " ,-")
+		if line != "" && line != "〒" {
+			lines = append(lines, line)
+		}
+	}
+
+	if region, err := language.ParseRegion(country); err == nil {
+		if langRegion, _ := lang.Region(); langRegion != region {
+			name := regionName(lang, region)
+			if bigEndianAddresses[country] {
+				lines = append([]string{name}, lines...)
+			} else {
+				lines = append(lines, strings.ToUpper(name))
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// regionName returns the name of a region in lang, in English if display has
+// no names for lang, or its code if it has none at all.
+func regionName(lang language.Tag, region language.Region) string {
+	namer := display.Regions(lang)
+	if namer == nil {
+		namer = display.English.Regions()
+	}
+	if name := namer.Name(region); name != "" {
+		return name
+	}
+	return region.String()
+}
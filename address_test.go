@@ -0,0 +1,73 @@
+package echoi18n
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// TestConfig_FormatAddress tests address layouts per destination country.
+func TestConfig_FormatAddress(t *testing.T) {
+	cfg := &Config{}
+	tests := []struct {
+		name string
+		lang language.Tag
+		addr Address
+		want string
+	}{
+		{
+			"domestic us", language.English,
+			Address{Name: "Jane Doe", Street: "1 Main St", City: "Springfield", Region: "IL", PostalCode: "62701", Country: "US"},
+			"Jane Doe\n1 Main St\nSpringfield, IL 62701",
+		},
+		{
+			"german from english", language.English,
+			Address{Name: "Max Mustermann", Street: "Hauptstraße 1", City: "Berlin", PostalCode: "10115", Country: "DE"},
+			"Max Mustermann\nHauptstraße 1\n10115 Berlin\nGERMANY",
+		},
+		{
+			"domestic china", language.Chinese,
+			Address{Name: "张三", Street: "长安街1号", City: "北京市", PostalCode: "100000", Country: "CN"},
+			"100000\n北京市\n长安街1号\n张三",
+		},
+		{
+			"china from english", language.English,
+			Address{Name: "Zhang San", Street: "1 Chang'an Ave", City: "Beijing", PostalCode: "100000", Country: "CN"},
+			"China\n100000\nBeijing\n1 Chang'an Ave\nZhang San",
+		},
+		{
+			"undetermined language", language.Und,
+			Address{City: "Berlin", Country: "DE"},
+			"Berlin\nGERMANY",
+		},
+		{
+			"unsupported language", language.Make("xx"),
+			Address{City: "Berlin", Country: "DE"},
+			"Berlin\nGERMANY",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, cfg.FormatAddress(tt.lang, tt.addr))
+		})
+	}
+}
+
+// TestFormatAddress tests formatting addresses for the negotiated language.
+func TestFormatAddress(t *testing.T) {
+	t.Parallel()
+	app := echo.New()
+	app.Use(NewMiddleware(&Config{}))
+	app.GET("/", func(c echo.Context) error {
+		return c.String(http.StatusOK, FormatAddress(c, Address{Name: "Jane Doe", City: "London", PostalCode: "SW1A 1AA", Country: "GB"}))
+	})
+
+	got, err := makeRequest(language.Chinese, "", app)
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(got.Body)
+	assert.Equal(t, "Jane Doe\nLondon\nSW1A 1AA\n英国", string(body))
+}
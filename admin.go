@@ -0,0 +1,134 @@
+package echoi18n
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// AdminConfig holds the authorization hooks of the admin translation API.
+// A nil hook allows the action.
+type AdminConfig struct {
+	CanRead    func(echo.Context) bool // Authorizes reading catalogs and stats.
+	CanWrite   func(echo.Context) bool // Authorizes editing translations.
+	CanPublish func(echo.Context) bool // Authorizes approving translations for serving.
+}
+
+// RegisterAdmin registers the admin translation API on the group:
+//
+//	GET /catalog/:lang      exported messages of a language
+//	GET /message/:lang/:id  metadata of a message
+//	GET /stats              translation completeness report
+//	GET /diff/:from/:to     message IDs changed between two catalog versions
+//	PUT /state/:lang/:id    change the workflow state of a translation
+//
+// The group must be served behind the i18n middleware.
+func RegisterAdmin(g *echo.Group, config ...AdminConfig) {
+	var admin AdminConfig
+	if len(config) > 0 {
+		admin = config[0]
+	}
+	g.GET("/catalog/:lang", admin.authorize(admin.CanRead, adminCatalog))
+	g.GET("/message/:lang/:id", admin.authorize(admin.CanRead, adminMessage))
+	g.GET("/stats", admin.authorize(admin.CanRead, adminStats))
+	g.GET("/diff/:from/:to", admin.authorize(admin.CanRead, adminDiff))
+	g.PUT("/state/:lang/:id", admin.setState)
+}
+
+// allowed reports whether the authorization hook allows the request.
+func allowed(hook func(echo.Context) bool, c echo.Context) bool {
+	return hook == nil || hook(c)
+}
+
+// authorize wraps a handler with an authorization hook.
+func (a AdminConfig) authorize(hook func(echo.Context) bool, next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if !allowed(hook, c) {
+			return echo.ErrForbidden
+		}
+		return next(c)
+	}
+}
+
+// adminCatalog responds with the exported messages of a language.
+func adminCatalog(c echo.Context) error {
+	appCfg, err := getConfig(c)
+	if err != nil {
+		return fmt.Errorf("i18n.Admin error: %v", err)
+	}
+	messages, err := appCfg.Export(c.Param("lang"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+	return c.JSON(http.StatusOK, messages)
+}
+
+// adminMessage responds with the metadata of a message.
+func adminMessage(c echo.Context) error {
+	appCfg, err := getConfig(c)
+	if err != nil {
+		return fmt.Errorf("i18n.Admin error: %v", err)
+	}
+	info, err := appCfg.Message(c.Param("lang"), c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+	return c.JSON(http.StatusOK, info)
+}
+
+// adminStats responds with the translation completeness report.
+func adminStats(c echo.Context) error {
+	appCfg, err := getConfig(c)
+	if err != nil {
+		return fmt.Errorf("i18n.Admin error: %v", err)
+	}
+	return c.JSON(http.StatusOK, appCfg.Stats())
+}
+
+// adminDiff responds with the changes between two catalog versions.
+func adminDiff(c echo.Context) error {
+	appCfg, err := getConfig(c)
+	if err != nil {
+		return fmt.Errorf("i18n.Admin error: %v", err)
+	}
+	diff, err := appCfg.Diff(c.Param("from"), c.Param("to"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+	return c.JSON(http.StatusOK, diff)
+}
+
+// setState changes the workflow state of a translation from the
+// {"state": "..."} request body. Approving requires CanPublish, any other
+// state change requires CanWrite. A ReadOnly Config rejects them all.
+func (a AdminConfig) setState(c echo.Context) error {
+	appCfg, err := getConfig(c)
+	if err != nil {
+		return fmt.Errorf("i18n.Admin error: %v", err)
+	}
+	var body struct {
+		State string `json:"state"`
+	}
+	if err := c.Bind(&body); err != nil {
+		return err
+	}
+
+	hook := a.CanWrite
+	if body.State == StateApproved {
+		hook = a.CanPublish
+	}
+	if !allowed(hook, c) {
+		return echo.ErrForbidden
+	}
+
+	err = appCfg.SetState(c.Param("lang"), c.Param("id"), body.State)
+	switch {
+	case errors.Is(err, ErrReadOnly):
+		return echo.NewHTTPError(http.StatusForbidden, err.Error())
+	case err != nil:
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,106 @@
+package echoi18n
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// newAdminServer creates an Echo server exposing the admin API under /admin.
+func newAdminServer(cfg *Config) *echo.Echo {
+	e := echo.New()
+	e.Use(NewMiddleware(cfg))
+	RegisterAdmin(e.Group("/admin"))
+	return e
+}
+
+// TestRegisterAdmin tests the admin catalog endpoint.
+func TestRegisterAdmin(t *testing.T) {
+	t.Parallel()
+	app := newAdminServer(&Config{RootPath: "testdata/localize"})
+
+	got, err := makeRequest(language.Und, "admin/catalog/en", app)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, got.StatusCode)
+	body, _ := io.ReadAll(got.Body)
+	assert.JSONEq(t, `[
+		{"id": "welcome", "description": "Greeting shown on the home page", "other": "hello"},
+		{"id": "welcomeWithName", "description": "Greeting with the visitor's name", "other": "hello {{ .name }}"}
+	]`, string(body))
+
+	got, err = makeRequest(language.Und, "admin/catalog/fr", app)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, got.StatusCode)
+}
+
+// TestRegisterAdmin_stats tests the admin stats endpoint.
+func TestRegisterAdmin_stats(t *testing.T) {
+	t.Parallel()
+	app := newAdminServer(&Config{RootPath: "testdata/goi18n", FilePrefix: "active."})
+
+	got, err := makeRequest(language.Und, "admin/stats", app)
+	assert.NoError(t, err)
+	var stats Stats
+	assert.NoError(t, json.NewDecoder(got.Body).Decode(&stats))
+	assert.Equal(t, "en", stats.DefaultLanguage)
+	assert.Equal(t, map[string]LanguageStats{
+		"en": {Total: 3, Translated: 3},
+		"zh": {Total: 3, Translated: 1, Missing: []string{"welcomeWithName"}, Outdated: []string{"goodbye"}},
+	}, stats.Languages)
+	assert.Equal(t, 2, stats.Memory.Languages["zh"].Messages)
+}
+
+// TestRegisterAdmin_authorization tests the per-action authorization hooks.
+func TestRegisterAdmin_authorization(t *testing.T) {
+	t.Parallel()
+	role := func(roles ...string) func(echo.Context) bool {
+		return func(c echo.Context) bool {
+			for _, r := range roles {
+				if c.Request().Header.Get("X-Role") == r {
+					return true
+				}
+			}
+			return false
+		}
+	}
+	app := echo.New()
+	app.Use(NewMiddleware(&Config{RootPath: "testdata/workflow"}))
+	RegisterAdmin(app.Group("/admin"), AdminConfig{
+		CanRead:    role("translator", "reviewer"),
+		CanWrite:   role("translator", "reviewer"),
+		CanPublish: role("reviewer"),
+	})
+
+	tests := []struct {
+		name   string
+		role   string
+		method string
+		url    string
+		body   string
+		code   int
+	}{
+		{"read anonymous", "", http.MethodGet, "/admin/stats", "", http.StatusForbidden},
+		{"read translator", "translator", http.MethodGet, "/admin/catalog/zh", "", http.StatusOK},
+		{"write translator", "translator", http.MethodPut, "/admin/state/zh/welcome", `{"state": "reviewed"}`, http.StatusNoContent},
+		{"publish translator", "translator", http.MethodPut, "/admin/state/zh/welcome", `{"state": "approved"}`, http.StatusForbidden},
+		{"publish reviewer", "reviewer", http.MethodPut, "/admin/state/zh/welcome", `{"state": "approved"}`, http.StatusNoContent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.url, strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set("X-Role", tt.role)
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+			assert.Equal(t, tt.code, rec.Code)
+		})
+	}
+}
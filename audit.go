@@ -0,0 +1,109 @@
+package echoi18n
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// DataAudit reports the template data keys of a Localize call that its
+// message did not use, or that it used without the call providing them.
+type DataAudit struct {
+	Lang      string   // Language of the message.
+	MessageID string   // ID of the message.
+	Used      []string // Keys used by the message, sorted.
+	Unused    []string // Keys provided but not used by the message, sorted.
+	Missing   []string // Keys used by the message but not provided, sorted.
+}
+
+// AuditHandler is called for each Localize call whose template data does not
+// match the keys its message uses, e.g. after a copy edit dropped a parameter.
+type AuditHandler func(c echo.Context, audit DataAudit)
+
+// languagePlaceholders returns the top-level template data keys used by each
+// message of lang, computed on first use.
+func (c *Config) languagePlaceholders(lang string) map[string][]string {
+	if keys, ok := c.placeholders.Load(lang); ok {
+		return keys.(map[string][]string)
+	}
+	keys := make(map[string][]string, len(c.messages[lang]))
+	for _, m := range c.messages[lang] {
+		fields, err := c.parser.placeholders(m)
+		if err != nil {
+			continue
+		}
+		keys[m.ID] = nil
+		seen := map[string]bool{}
+		for _, field := range fields {
+			key, _, _ := strings.Cut(field, ".")
+			if !seen[key] {
+				seen[key] = true
+				keys[m.ID] = append(keys[m.ID], key)
+			}
+		}
+	}
+	actual, _ := c.placeholders.LoadOrStore(lang, keys)
+	return actual.(map[string][]string)
+}
+
+// dataKeys returns the top-level keys of template data: the keys of a map
+// or the exported fields of a struct.
+func dataKeys(data interface{}) map[string]bool {
+	keys := map[string]bool{}
+	rv := reflect.ValueOf(data)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.Map:
+		if rv.Type().Key().Kind() == reflect.String {
+			for _, key := range rv.MapKeys() {
+				keys[key.String()] = true
+			}
+		}
+	case reflect.Struct:
+		for i := 0; i < rv.NumField(); i++ {
+			if field := rv.Type().Field(i); field.IsExported() {
+				keys[field.Name] = true
+			}
+		}
+	}
+	return keys
+}
+
+// auditData compares the template data of a Localize call with the keys its
+// message uses in lang, or in the default language if lang lacks it, and
+// reports mismatches to AuditData. Messages of namespace shards and default
+// messages are not audited. Injected ProtectedTerms count as provided.
+func (c *Config) auditData(ctx echo.Context, lang string, lc *i18n.LocalizeConfig) {
+	used, ok := c.languagePlaceholders(lang)[lc.MessageID]
+	if !ok {
+		used, ok = c.languagePlaceholders(c.DefaultLanguage.String())[lc.MessageID]
+	}
+	if !ok {
+		return
+	}
+
+	provided := dataKeys(lc.TemplateData)
+	if lc.TemplateData == nil && lc.PluralCount != nil {
+		provided["PluralCount"] = true
+	}
+	audit := DataAudit{Lang: lang, MessageID: lc.MessageID, Used: used}
+	for _, key := range used {
+		_, injected := c.ProtectedTerms[key]
+		if !provided[key] && !(c.InjectTerms && injected) {
+			audit.Missing = append(audit.Missing, key)
+		}
+		delete(provided, key)
+	}
+	for key := range provided {
+		audit.Unused = append(audit.Unused, key)
+	}
+	sort.Strings(audit.Unused)
+	if len(audit.Unused) > 0 || len(audit.Missing) > 0 {
+		c.AuditData(ctx, audit)
+	}
+}
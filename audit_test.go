@@ -0,0 +1,51 @@
+package echoi18n
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// TestConfig_AuditData tests reporting unused and missing template data keys.
+func TestConfig_AuditData(t *testing.T) {
+	t.Parallel()
+	var mu sync.Mutex
+	var audits []DataAudit
+	app := echo.New()
+	app.Use(NewMiddleware(&Config{
+		Bundles: []Bundle{{
+			"en": {{ID: "order", Other: "{{ .user.name }} ordered {{ .count }} items"}},
+			"zh": {{ID: "order", Other: "{{ .user.name }} 订购了商品"}},
+		}},
+		AuditData: func(c echo.Context, audit DataAudit) {
+			mu.Lock()
+			defer mu.Unlock()
+			audits = append(audits, audit)
+		},
+	}))
+	app.GET("/", func(c echo.Context) error {
+		data := map[string]interface{}{"user": map[string]string{"name": "Ann"}, "count": 2}
+		MustLocalize(c, &i18n.LocalizeConfig{MessageID: "order", TemplateData: data})
+		MustLocalize(c, &i18n.LocalizeConfig{MessageID: "welcomeWithName"})
+		MustLocalize(c, "welcome")
+		return c.NoContent(http.StatusOK)
+	})
+
+	_, err := makeRequest(language.English, "", app)
+	assert.NoError(t, err)
+	_, err = makeRequest(language.Chinese, "", app)
+	assert.NoError(t, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []DataAudit{
+		{Lang: "en", MessageID: "welcomeWithName", Used: []string{"name"}, Missing: []string{"name"}},
+		{Lang: "zh", MessageID: "order", Used: []string{"user"}, Unused: []string{"count"}},
+		{Lang: "zh", MessageID: "welcomeWithName", Used: []string{"name"}, Missing: []string{"name"}},
+	}, audits)
+}
@@ -0,0 +1,37 @@
+package echoi18n
+
+import (
+	"github.com/labstack/echo/v4"
+	"golang.org/x/text/language"
+)
+
+// initBeta indexes the BetaLanguages and builds the matcher of the other
+// supported languages.
+func (c *Config) initBeta() {
+	if len(c.BetaLanguages) == 0 {
+		return
+	}
+	c.beta = make(map[string]bool, len(c.BetaLanguages))
+	for _, tag := range c.BetaLanguages {
+		c.beta[tag.String()] = true
+	}
+	public := make([]language.Tag, 0, len(c.AcceptLanguages))
+	for _, tag := range c.AcceptLanguages {
+		if !c.beta[tag.String()] {
+			public = append(public, tag)
+		}
+	}
+	c.publicAccept = newAcceptMatcher(public, c.PreferredScripts)
+}
+
+// betaAllowed reports whether BetaAccess allows the request to negotiate the
+// BetaLanguages.
+func (c *Config) betaAllowed(ctx echo.Context) bool {
+	return ctx != nil && c.BetaAccess != nil && c.BetaAccess(ctx)
+}
+
+// betaHidden reports whether lang is a beta language the request may not
+// negotiate, so that it is served the default language instead.
+func (c *Config) betaHidden(ctx echo.Context, lang string) bool {
+	return c.beta[lang] && !c.betaAllowed(ctx)
+}
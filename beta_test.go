@@ -0,0 +1,61 @@
+package echoi18n
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// TestConfig_BetaLanguages tests negotiating soft-launched languages only
+// for the requests allowed by BetaAccess.
+func TestConfig_BetaLanguages(t *testing.T) {
+	t.Parallel()
+	app := echo.New()
+	app.Use(NewMiddleware(&Config{
+		AcceptLanguages: []language.Tag{language.English, language.Chinese, language.French},
+		Loader:          LoaderFunc(func(path string) ([]byte, error) { return nil, nil }),
+		BetaLanguages:   []language.Tag{language.French},
+		BetaAccess: func(c echo.Context) bool {
+			return c.Request().Header.Get("X-Beta") == "1"
+		},
+		DebugHeaders: true,
+	}))
+	app.GET("/", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	tests := []struct {
+		name       string
+		url        string
+		header     string
+		beta       bool
+		wantLang   string
+		wantSource string
+	}{
+		{"public header", "/", "zh", false, "zh", SourceHeader},
+		{"beta header", "/", "fr", false, "en", SourceDefault},
+		{"beta header next choice", "/", "fr, zh;q=0.8", false, "zh", SourceHeader},
+		{"beta query", "/?lang=fr", "", false, "en", SourceFallback},
+		{"allowed header", "/", "fr, zh;q=0.8", true, "fr", SourceHeader},
+		{"allowed query", "/?lang=fr", "", true, "fr", SourceQuery},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			if tt.header != "" {
+				req.Header.Set("Accept-Language", tt.header)
+			}
+			if tt.beta {
+				req.Header.Set("X-Beta", "1")
+			}
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+			assert.Equal(t, tt.wantLang, rec.Header().Get(HeaderLanguage))
+			assert.Equal(t, tt.wantSource, rec.Header().Get(HeaderSource))
+		})
+	}
+}
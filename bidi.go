@@ -0,0 +1,70 @@
+package echoi18n
+
+import (
+	"fmt"
+	"reflect"
+
+	"golang.org/x/text/language"
+)
+
+// Unicode bidi isolation characters.
+const (
+	firstStrongIsolate    = "⁨"
+	popDirectionalIsolate = "⁩"
+)
+
+// rtlScripts are the scripts written right-to-left.
+var rtlScripts = map[string]bool{
+	"Adlm": true, "Arab": true, "Hebr": true, "Mand": true, "Nkoo": true,
+	"Rohg": true, "Samr": true, "Syrc": true, "Thaa": true,
+}
+
+// IsRTL reports whether the language is written right-to-left, based on its
+// explicit or most likely script.
+func IsRTL(tag language.Tag) bool {
+	script, _ := tag.Script()
+	return rtlScripts[script.String()]
+}
+
+// mapTemplateData returns a copy of map or struct template data with fn
+// applied to every value, as a map keyed by the map keys or the exported
+// field names, so templates read the same values. Methods of struct data are
+// not available to templates on the copy. Other kinds of template data are
+// returned unchanged.
+func mapTemplateData(data interface{}, fn func(key string, value interface{}) interface{}) interface{} {
+	rv := reflect.ValueOf(data)
+	for (rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface) && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	switch {
+	case rv.Kind() == reflect.Map && rv.Type().Key().Kind() == reflect.String:
+		mapped := make(map[string]interface{}, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			key := iter.Key().String()
+			mapped[key] = fn(key, iter.Value().Interface())
+		}
+		return mapped
+	case rv.Kind() == reflect.Struct:
+		mapped := make(map[string]interface{}, rv.NumField())
+		for i := 0; i < rv.NumField(); i++ {
+			if field := rv.Type().Field(i); field.IsExported() {
+				mapped[field.Name] = fn(field.Name, rv.Field(i).Interface())
+			}
+		}
+		return mapped
+	}
+	return data
+}
+
+// bidiIsolate wraps string and Stringer values in FSI/PDI characters so their
+// direction does not garble the surrounding right-to-left text.
+func bidiIsolate(_ string, value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		return firstStrongIsolate + v + popDirectionalIsolate
+	case fmt.Stringer:
+		return firstStrongIsolate + v.String() + popDirectionalIsolate
+	}
+	return value
+}
@@ -0,0 +1,66 @@
+package echoi18n
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// TestConfig_BidiIsolate tests isolating interpolated values in RTL languages.
+func TestConfig_BidiIsolate(t *testing.T) {
+	t.Parallel()
+	app := echo.New()
+	app.Use(NewMiddleware(&Config{
+		AcceptLanguages: []language.Tag{language.English, language.Arabic},
+		BidiIsolate:     true,
+		Loader: LoaderFunc(func(path string) ([]byte, error) {
+			return nil, nil
+		}),
+		Bundles: []Bundle{{
+			"en": {{ID: "welcomeWithName", Other: "hello {{ .name }}"}},
+			"ar": {{ID: "welcomeWithName", Other: "مرحبا {{ .name }}"}},
+		}},
+	}))
+	app.GET("/:name", func(c echo.Context) error {
+		return c.String(http.StatusOK, MustLocalize(c, &i18n.LocalizeConfig{
+			MessageID:    "welcomeWithName",
+			TemplateData: map[string]interface{}{"name": c.Param("name")},
+		}))
+	})
+
+	tests := []struct {
+		lang language.Tag
+		want string
+	}{
+		{language.Arabic, "مرحبا ⁨alex⁩"},
+		{language.English, "hello alex"},
+	}
+	for _, tt := range tests {
+		got, err := makeRequest(tt.lang, "alex", app)
+		assert.NoError(t, err)
+		body, _ := io.ReadAll(got.Body)
+		assert.Equal(t, tt.want, string(body))
+	}
+}
+
+// TestIsRTL tests detecting right-to-left languages.
+func TestIsRTL(t *testing.T) {
+	assert.True(t, IsRTL(language.Arabic))
+	assert.True(t, IsRTL(language.Hebrew))
+	assert.True(t, IsRTL(language.MustParse("fa")))
+	assert.False(t, IsRTL(language.Chinese))
+	assert.False(t, IsRTL(language.MustParse("az-Latn")))
+}
+
+// Test_mapTemplateData tests transforming map and struct template data values.
+func Test_mapTemplateData(t *testing.T) {
+	replace := func(string, interface{}) interface{} { return "x" }
+	assert.Equal(t, map[string]interface{}{"a": "x"}, mapTemplateData(map[string]int{"a": 1}, replace))
+	assert.Equal(t, map[string]interface{}{"A": "x"}, mapTemplateData(&struct{ A, b int }{1, 2}, replace))
+	assert.Equal(t, 1, mapTemplateData(1, replace))
+}
@@ -0,0 +1,120 @@
+package echoi18n
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// defaultBudgetWindow is the number of Localize calls per language the
+// budget rates are measured over by default.
+const defaultBudgetWindow = 1000
+
+// Budget kinds reported by BudgetAlert.
+const (
+	BudgetMiss  = "miss"  // Messages missing in the request language.
+	BudgetError = "error" // Other Localize errors.
+)
+
+// BudgetAlert describes a language exceeding its miss or error budget over a
+// window of Localize calls.
+type BudgetAlert struct {
+	Language string  // Request language.
+	Kind     string  // BudgetMiss or BudgetError.
+	Rate     float64 // Measured rate over the window.
+	Budget   float64 // Configured budget.
+	Calls    int     // Localize calls of the window.
+}
+
+// BudgetHandler is called when a language exceeds a budget, e.g. to page the
+// team after a broken translation push.
+type BudgetHandler func(alert BudgetAlert)
+
+// budgetCounts counts the outcomes of the Localize calls of a window.
+type budgetCounts struct {
+	calls  int
+	misses int
+	errors int
+}
+
+// budgetMeter measures the miss and error rates of each language over
+// tumbling windows of Localize calls. It outlives the catalog snapshots.
+type budgetMeter struct {
+	mu     sync.Mutex
+	counts map[string]*budgetCounts // Counts of the current window by language.
+}
+
+// newBudgetMeter creates an empty budget meter.
+func newBudgetMeter() *budgetMeter {
+	return &budgetMeter{counts: map[string]*budgetCounts{}}
+}
+
+// record counts a Localize outcome of lang and returns the counts of the
+// window if the call completed it.
+func (b *budgetMeter) record(lang string, err error, window int) (budgetCounts, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	counts, ok := b.counts[lang]
+	if !ok {
+		counts = &budgetCounts{}
+		b.counts[lang] = counts
+	}
+	counts.calls++
+	var notFound *i18n.MessageNotFoundErr
+	switch {
+	case errors.As(err, &notFound):
+		counts.misses++
+	case err != nil:
+		counts.errors++
+	}
+	if counts.calls < window {
+		return budgetCounts{}, false
+	}
+	completed := *counts
+	*counts = budgetCounts{}
+	return completed, true
+}
+
+// budgetWindow returns the configured budget window or its default.
+func (c *Config) budgetWindow() int {
+	if c.BudgetWindow > 0 {
+		return c.BudgetWindow
+	}
+	return defaultBudgetWindow
+}
+
+// observe records the outcome of a Localize call of the request and calls
+// BudgetExceeded for each budget the request language exceeded when the call
+// completes a window.
+func (c *Config) observe(ctx echo.Context, err error) {
+	meter := c.root().budgets
+	if meter == nil || c.BudgetExceeded == nil || (c.MissBudget <= 0 && c.ErrorBudget <= 0) {
+		return
+	}
+	lang := c.language(ctx)
+	counts, ok := meter.record(lang, err, c.budgetWindow())
+	if !ok {
+		return
+	}
+	for _, budget := range []struct {
+		kind   string
+		count  int
+		budget float64
+	}{
+		{BudgetMiss, counts.misses, c.MissBudget},
+		{BudgetError, counts.errors, c.ErrorBudget},
+	} {
+		rate := float64(budget.count) / float64(counts.calls)
+		if budget.budget > 0 && rate > budget.budget {
+			c.BudgetExceeded(BudgetAlert{
+				Language: lang,
+				Kind:     budget.kind,
+				Rate:     rate,
+				Budget:   budget.budget,
+				Calls:    counts.calls,
+			})
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package echoi18n
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// TestConfig_BudgetExceeded tests alerting about languages exceeding their budgets.
+func TestConfig_BudgetExceeded(t *testing.T) {
+	t.Parallel()
+	var mu sync.Mutex
+	var alerts []BudgetAlert
+	app := echo.New()
+	app.Use(NewMiddleware(&Config{
+		MissBudget:   0.25,
+		ErrorBudget:  0.25,
+		BudgetWindow: 4,
+		BudgetExceeded: func(alert BudgetAlert) {
+			mu.Lock()
+			defer mu.Unlock()
+			alerts = append(alerts, alert)
+		},
+	}))
+	app.GET("/", func(c echo.Context) error {
+		var params interface{} = 42
+		if id := c.QueryParam("id"); id != "" {
+			params = id
+		}
+		_, err := Localize(c, params)
+		if err != nil {
+			return c.NoContent(http.StatusNotFound)
+		}
+		return c.NoContent(http.StatusOK)
+	})
+
+	requests := []struct {
+		lang language.Tag
+		url  string
+	}{
+		{language.Chinese, "?id=welcome"},
+		{language.Chinese, "?id=missing"},
+		{language.English, "?id=missing"},
+		{language.Chinese, "?id=missing"},
+		{language.Chinese, "?id=welcome"},
+		{language.Chinese, ""},
+		{language.Chinese, "?id=welcome"},
+		{language.Chinese, "?id=missing"},
+		{language.Chinese, "?id=welcome"},
+	}
+	for _, r := range requests {
+		_, err := makeRequest(r.lang, r.url, app)
+		assert.NoError(t, err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []BudgetAlert{
+		{Language: "zh", Kind: BudgetMiss, Rate: 0.5, Budget: 0.25, Calls: 4},
+	}, alerts)
+}
+
+// TestBudgetMeter tests measuring the rates over tumbling windows.
+func TestBudgetMeter(t *testing.T) {
+	t.Parallel()
+	meter := newBudgetMeter()
+
+	_, ok := meter.record("zh", nil, 2)
+	assert.False(t, ok)
+	counts, ok := meter.record("zh", assert.AnError, 2)
+	assert.True(t, ok)
+	assert.Equal(t, budgetCounts{calls: 2, errors: 1}, counts)
+
+	_, ok = meter.record("zh", nil, 2)
+	assert.False(t, ok)
+}
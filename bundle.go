@@ -0,0 +1,59 @@
+package echoi18n
+
+import (
+	"fmt"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// Bundle is a set of messages per language built independently of the
+// middleware, e.g. the translations shipped by a reusable library.
+type Bundle map[string][]*i18n.Message
+
+// ParseBundle builds a Bundle from message files read through the loader.
+// File names follow the <lang>.<format> convention; formats missing from
+// unmarshalFuncs are parsed with the registered formats.
+func ParseBundle(loader Loader, unmarshalFuncs map[string]i18n.UnmarshalFunc, paths ...string) (Bundle, error) {
+	funcs := formatUnmarshalFuncs()
+	for format, unmarshalFunc := range unmarshalFuncs {
+		funcs[format] = unmarshalFunc
+	}
+
+	bundle := Bundle{}
+	for _, path := range paths {
+		buf, err := loader.LoadMessage(path)
+		if err != nil {
+			return nil, fmt.Errorf("i18n.ParseBundle error: %v", err)
+		}
+		messageFile, err := i18n.ParseMessageFileBytes(buf, path, funcs)
+		if err != nil {
+			return nil, fmt.Errorf("i18n.ParseBundle error: %s: %v", path, err)
+		}
+		lang := messageFile.Tag.String()
+		bundle[lang] = append(bundle[lang], messageFile.Messages...)
+	}
+	return bundle, nil
+}
+
+// MergeBundles merges bundles in increasing precedence: a message of a later
+// bundle replaces the message with the same language and ID of an earlier one.
+func MergeBundles(bundles ...Bundle) Bundle {
+	merged := Bundle{}
+	index := map[string]map[string]int{}
+	for _, bundle := range bundles {
+		for lang, messages := range bundle {
+			if index[lang] == nil {
+				index[lang] = map[string]int{}
+			}
+			for _, m := range messages {
+				if i, ok := index[lang][m.ID]; ok {
+					merged[lang][i] = m
+					continue
+				}
+				index[lang][m.ID] = len(merged[lang])
+				merged[lang] = append(merged[lang], m)
+			}
+		}
+	}
+	return merged
+}
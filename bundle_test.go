@@ -0,0 +1,94 @@
+package echoi18n
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// TestMergeBundles tests merging bundles with increasing precedence.
+func TestMergeBundles(t *testing.T) {
+	library := Bundle{"en": {{ID: "welcome", Other: "hi"}, {ID: "error", Other: "oops"}}}
+	app := Bundle{"en": {{ID: "welcome", Other: "hello"}}, "zh": {{ID: "welcome", Other: "你好"}}}
+
+	assert.Equal(t, Bundle{
+		"en": {{ID: "welcome", Other: "hello"}, {ID: "error", Other: "oops"}},
+		"zh": {{ID: "welcome", Other: "你好"}},
+	}, MergeBundles(library, app))
+}
+
+// TestConfig_Bundles tests inheriting library messages overridden by files.
+func TestConfig_Bundles(t *testing.T) {
+	t.Parallel()
+	library := Bundle{
+		"en": {{ID: "welcome", Other: "hi"}, {ID: "library.error", Other: "something went wrong"}},
+		"zh": {{ID: "library.error", Other: "出错了"}},
+	}
+	app := echo.New()
+	app.Use(NewMiddleware(&Config{Bundles: []Bundle{library}}))
+	app.GET("/:name", func(c echo.Context) error {
+		return c.String(http.StatusOK, MustLocalize(c, &i18n.LocalizeConfig{MessageID: c.Param("name")}))
+	})
+
+	tests := []struct {
+		name string
+		lang language.Tag
+		url  string
+		want string
+	}{
+		{"file overrides library", language.English, "welcome", "hello"},
+		{"library message", language.English, "library.error", "something went wrong"},
+		{"library translation", language.Chinese, "library.error", "出错了"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := makeRequest(tt.lang, tt.url, app)
+			assert.NoError(t, err)
+			body, _ := io.ReadAll(got.Body)
+			assert.Equal(t, tt.want, string(body))
+		})
+	}
+}
+
+// TestConfig_Bundles_withoutFiles tests serving precompiled bundles without message files.
+func TestConfig_Bundles_withoutFiles(t *testing.T) {
+	t.Parallel()
+	app := echo.New()
+	app.Use(NewMiddleware(&Config{
+		Bundles: []Bundle{{"en": {{ID: "welcome", Other: "hi"}}, "zh": {{ID: "welcome", Other: "嗨"}}}},
+		Loader: LoaderFunc(func(path string) ([]byte, error) {
+			return nil, os.ErrNotExist
+		}),
+	}))
+	app.GET("/", func(c echo.Context) error {
+		return c.String(http.StatusOK, MustLocalize(c, "welcome"))
+	})
+
+	got, err := makeRequest(language.Chinese, "", app)
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(got.Body)
+	assert.Equal(t, "嗨", string(body))
+	assert.Panics(t, func() {
+		NewMiddleware(&Config{Loader: LoaderFunc(func(path string) ([]byte, error) {
+			return nil, os.ErrNotExist
+		})})
+	})
+}
+
+// TestParseBundle tests building a bundle from message files.
+func TestParseBundle(t *testing.T) {
+	got, err := ParseBundle(LoaderFunc(func(path string) ([]byte, error) {
+		return []byte(`{"welcome": "hello"}`), nil
+	}), nil, "lib/en.json")
+	assert.NoError(t, err)
+	assert.Equal(t, Bundle{"en": {{ID: "welcome", Other: "hello"}}}, got)
+
+	_, err = ParseBundle(mapLoader, nil, "lib/en.json")
+	assert.EqualError(t, err, "i18n.ParseBundle error: file does not exist")
+}
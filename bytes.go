@@ -0,0 +1,71 @@
+package echoi18n
+
+import (
+	"github.com/labstack/echo/v4"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// byteUnits are the decimal and binary byte units of a language.
+type byteUnits struct {
+	decimal   []string
+	binary    []string
+	separator string // Space between the number and the unit.
+}
+
+// defaultByteUnits are the byte units of languages without specific ones.
+var defaultByteUnits = byteUnits{
+	decimal:   []string{"B", "kB", "MB", "GB", "TB", "PB", "EB"},
+	binary:    []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"},
+	separator: " ",
+}
+
+// byteUnitLanguages are the byte units keyed by base language.
+var byteUnitLanguages = map[string]byteUnits{
+	"fr": {
+		decimal:   []string{"o", "ko", "Mo", "Go", "To", "Po", "Eo"},
+		binary:    []string{"o", "Kio", "Mio", "Gio", "Tio", "Pio", "Eio"},
+		separator: "\u00a0",
+	},
+}
+
+// formatBytes formats a size of n bytes in lang with one fraction digit at
+// most, in powers of 1024 if binary is set and powers of 1000 otherwise.
+func formatBytes(lang language.Tag, n int64, binary bool) string {
+	base, _ := lang.Base()
+	units, ok := byteUnitLanguages[base.String()]
+	if !ok {
+		units = defaultByteUnits
+	}
+	names, step := units.decimal, 1000.0
+	if binary {
+		names, step = units.binary, 1024.0
+	}
+
+	value := float64(n)
+	if value < 0 {
+		value = -value
+	}
+	unit := 0
+	for value >= step && unit < len(names)-1 {
+		value /= step
+		unit++
+	}
+	if n < 0 {
+		value = -value
+	}
+	formatted := message.NewPrinter(lang).Sprint(number.Decimal(value, number.MaxFractionDigits(1)))
+	return formatted + units.separator + names[unit]
+}
+
+// FormatBytes formats a size of n bytes for the negotiated language with its
+// decimal separator and unit conventions, e.g. "1.5 MB" or "1,5 Mo" in French.
+// Config.BinaryBytes selects binary units such as MiB.
+func FormatBytes(c echo.Context, n int64) string {
+	appCfg, err := getConfig(c)
+	if err != nil {
+		return formatBytes(language.Und, n, false)
+	}
+	return formatBytes(language.Make(appCfg.language(c)), n, appCfg.BinaryBytes)
+}
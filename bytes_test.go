@@ -0,0 +1,50 @@
+package echoi18n
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// Test_formatBytes tests decimal and binary sizes in several languages.
+func Test_formatBytes(t *testing.T) {
+	tests := []struct {
+		lang   language.Tag
+		n      int64
+		binary bool
+		want   string
+	}{
+		{language.English, 512, false, "512 B"},
+		{language.English, 1500000, false, "1.5 MB"},
+		{language.English, 2000, false, "2 kB"},
+		{language.English, 1536, true, "1.5 KiB"},
+		{language.English, -2048, true, "-2 KiB"},
+		{language.French, 1500000, false, "1,5\u00a0Mo"},
+		{language.French, 1 << 30, true, "1\u00a0Gio"},
+		{language.German, 1234567890, false, "1,2 GB"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, formatBytes(tt.lang, tt.n, tt.binary))
+		})
+	}
+}
+
+// TestFormatBytes tests sizes in the negotiated language.
+func TestFormatBytes(t *testing.T) {
+	t.Parallel()
+	app := echo.New()
+	app.Use(NewMiddleware(&Config{BinaryBytes: true}))
+	app.GET("/", func(c echo.Context) error {
+		return c.String(http.StatusOK, FormatBytes(c, 3<<20))
+	})
+
+	got, err := makeRequest(language.Chinese, "", app)
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(got.Body)
+	assert.Equal(t, "3 MiB", string(body))
+}
@@ -0,0 +1,41 @@
+package echoi18n
+
+import (
+	"github.com/labstack/echo/v4"
+	"golang.org/x/text/language"
+)
+
+// CacheKeyContextKey is the Echo Context key of the cache-key fragment, for
+// response-caching middleware running after the i18n middleware.
+const CacheKeyContextKey = "echoi18n.cacheKey"
+
+// HeaderCacheKey is the conventional response header of the cache-key fragment.
+const HeaderCacheKey = "X-I18n-Cache-Key"
+
+// CacheKey returns the cache-key fragment of the request: the canonical tag
+// of the negotiated language, e.g. "zh-Hant". Caches must vary responses
+// localized by the middleware on this value. Returns "" without the middleware.
+func CacheKey(c echo.Context) string {
+	if key, ok := c.Get(CacheKeyContextKey).(string); ok {
+		return key
+	}
+	appCfg, err := getConfig(c)
+	if err != nil {
+		return ""
+	}
+	key := language.Make(appCfg.resolve(c).lang).String()
+	c.Set(CacheKeyContextKey, key)
+	return key
+}
+
+// languageChanged recomputes the negotiation results exposed in the context
+// after the request language changed.
+func languageChanged(c echo.Context) {
+	unresolve(c)
+	c.Set(NegotiationContextKey, nil)
+	c.Set(LanguageContextKey, nil)
+	if _, ok := c.Get(CacheKeyContextKey).(string); ok {
+		c.Set(CacheKeyContextKey, nil)
+		CacheKey(c)
+	}
+}
@@ -0,0 +1,41 @@
+package echoi18n
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// TestCacheKey tests exposing the cache-key fragment in the context and header.
+func TestCacheKey(t *testing.T) {
+	t.Parallel()
+	app := echo.New()
+	app.Use(NewMiddleware(&Config{CacheKeyHeader: HeaderCacheKey}))
+	app.GET("/", func(c echo.Context) error {
+		return c.String(http.StatusOK, c.Get(CacheKeyContextKey).(string))
+	})
+
+	tests := []struct {
+		name string
+		lang language.Tag
+		want string
+	}{
+		{"supported", language.Chinese, "zh"},
+		{"unsupported", language.French, "en"},
+		{"default", language.Und, "en"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := makeRequest(tt.lang, "", app)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got.Header.Get(HeaderCacheKey))
+		})
+	}
+
+	c := app.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+	assert.Equal(t, "", CacheKey(c))
+}
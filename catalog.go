@@ -0,0 +1,55 @@
+package echoi18n
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// CatalogMessage is an exported message, including the description and hash
+// written for translators.
+type CatalogMessage struct {
+	ID          string `json:"id" yaml:"id"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Hash        string `json:"hash,omitempty" yaml:"hash,omitempty"`
+	Zero        string `json:"zero,omitempty" yaml:"zero,omitempty"`
+	One         string `json:"one,omitempty" yaml:"one,omitempty"`
+	Two         string `json:"two,omitempty" yaml:"two,omitempty"`
+	Few         string `json:"few,omitempty" yaml:"few,omitempty"`
+	Many        string `json:"many,omitempty" yaml:"many,omitempty"`
+	Other       string `json:"other,omitempty" yaml:"other,omitempty"`
+}
+
+// newCatalogMessage converts a go-i18n message to a CatalogMessage.
+func newCatalogMessage(m *i18n.Message) CatalogMessage {
+	return CatalogMessage{
+		ID:          m.ID,
+		Description: m.Description,
+		Hash:        m.Hash,
+		Zero:        m.Zero,
+		One:         m.One,
+		Two:         m.Two,
+		Few:         m.Few,
+		Many:        m.Many,
+		Other:       m.Other,
+	}
+}
+
+// Export returns the messages loaded for a language sorted by ID.
+func (c *Config) Export(lang string) ([]CatalogMessage, error) {
+	c = c.active()
+	messages, ok := c.messages[lang]
+	if !ok {
+		return nil, fmt.Errorf("i18n.Export error: language %q not loaded", lang)
+	}
+
+	exported := make([]CatalogMessage, 0, len(messages))
+	for _, m := range messages {
+		exported = append(exported, newCatalogMessage(m))
+	}
+	sort.Slice(exported, func(i, j int) bool {
+		return exported[i].ID < exported[j].ID
+	})
+	return exported, nil
+}
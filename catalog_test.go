@@ -0,0 +1,36 @@
+package echoi18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestdataConfig builds a middleware config loading testdata/localize.
+func newTestdataConfig() *Config {
+	cfg := &Config{RootPath: "testdata/localize"}
+	NewMiddleware(cfg)
+	return cfg
+}
+
+// TestConfig_Export tests exporting messages with translator context.
+func TestConfig_Export(t *testing.T) {
+	t.Parallel()
+	cfg := newTestdataConfig()
+
+	got, err := cfg.Export("en")
+	assert.NoError(t, err)
+	assert.Equal(t, []CatalogMessage{
+		{ID: "welcome", Description: "Greeting shown on the home page", Other: "hello"},
+		{ID: "welcomeWithName", Description: "Greeting with the visitor's name", Other: "hello {{ .name }}"},
+	}, got)
+
+	got, err = cfg.Export("zh")
+	assert.NoError(t, err)
+	assert.Equal(t, []CatalogMessage{
+		{ID: "welcome", Hash: "sha1-aaf4c61ddcc5e8a2dabede0f3b482cd9aea9434d", Other: "你好"},
+	}, got)
+
+	_, err = cfg.Export("fr")
+	assert.EqualError(t, err, `i18n.Export error: language "fr" not loaded`)
+}
@@ -0,0 +1,71 @@
+package echoi18n
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"golang.org/x/text/language"
+)
+
+// LanguageVariant is a language version of a resource listed in a 300
+// Multiple Choices response.
+type LanguageVariant struct {
+	Language string `json:"language"`
+	URL      string `json:"url"`
+}
+
+// acceptMatches reports whether a supported language satisfies a tag of the
+// Accept-Language header: the same tag, a more specific tag of the same
+// language, or any language for "*".
+func acceptMatches(accepted, supported language.Tag) bool {
+	if accepted == supported || accepted.String() == "*" {
+		return true
+	}
+	acceptedBase, _ := accepted.Base()
+	supportedBase, _ := supported.Base()
+	return accepted == language.Make(acceptedBase.String()) && acceptedBase == supportedBase
+}
+
+// equalMatches returns the supported languages matching the highest quality
+// of the Accept-Language header in the order of AcceptLanguages. A single
+// result means the preference is unambiguous.
+func (c *Config) equalMatches(r *http.Request) []string {
+	tags, qualities, err := language.ParseAcceptLanguage(r.Header.Get("Accept-Language"))
+	if err != nil {
+		return nil
+	}
+	var best float32
+	var matches []string
+	for _, supported := range c.AcceptLanguages {
+		var q float32
+		for i, accepted := range tags {
+			if qualities[i] > q && acceptMatches(accepted, supported) {
+				q = qualities[i]
+			}
+		}
+		switch {
+		case q == 0 || q < best:
+		case q > best:
+			best, matches = q, []string{supported.String()}
+		default:
+			matches = append(matches, supported.String())
+		}
+	}
+	return matches
+}
+
+// multipleChoices responds 300 Multiple Choices with the language variants of
+// the route as JSON body and alternate Link headers.
+func (c *Config) multipleChoices(ctx echo.Context, route string, langs []string) error {
+	variants := make([]LanguageVariant, len(langs))
+	links := make([]string, len(langs))
+	for i, lang := range langs {
+		variants[i] = LanguageVariant{Language: lang, URL: c.LocalizedPath(lang, route)}
+		links[i] = fmt.Sprintf(`<%s>; rel="alternate"; hreflang="%s"`, variants[i].URL, lang)
+	}
+	ctx.Response().Header().Set("Link", strings.Join(links, ", "))
+	addVary(ctx.Response().Header(), "Accept-Language")
+	return ctx.JSON(http.StatusMultipleChoices, map[string][]LanguageVariant{"variants": variants})
+}
@@ -0,0 +1,56 @@
+package echoi18n
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// TestRootRedirectHandler_multipleChoices tests answering equal preferences with 300.
+func TestRootRedirectHandler_multipleChoices(t *testing.T) {
+	t.Parallel()
+	app := echo.New()
+	app.Use(NewMiddleware(&Config{
+		AcceptLanguages: []language.Tag{language.English, language.Chinese, language.German},
+		Loader:          LoaderFunc(func(path string) ([]byte, error) { return nil, nil }),
+		MultipleChoices: true,
+	}))
+	app.GET("/", RootRedirectHandler(nil))
+
+	tests := []struct {
+		name       string
+		header     string
+		wantStatus int
+		wantBody   string
+		wantLink   string
+	}{
+		{"preferred language", "zh, en;q=0.8", http.StatusFound, "", ""},
+		{"equal languages", "zh, de;q=0.9, en;q=0.9", http.StatusFound, "", ""},
+		{
+			"ambiguous", "de-CH;q=0.5, zh, en", http.StatusMultipleChoices,
+			`{"variants":[{"language":"en","url":"/en/"},{"language":"zh","url":"/zh/"}]}`,
+			`</en/>; rel="alternate"; hreflang="en", </zh/>; rel="alternate"; hreflang="zh"`,
+		},
+		{"base language", "de, en", http.StatusMultipleChoices, "", ""},
+		{"no header", "", http.StatusFound, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Accept-Language", tt.header)
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantBody != "" {
+				body, _ := io.ReadAll(rec.Body)
+				assert.JSONEq(t, tt.wantBody, string(body))
+				assert.Equal(t, tt.wantLink, rec.Header().Get("Link"))
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package echoi18n
+
+import (
+	"math/rand"
+	"time"
+)
+
+// TimerFunc returns a channel receiving the current time once d elapsed, like
+// time.After, so background reloads can be driven by tests.
+type TimerFunc func(d time.Duration) <-chan time.Time
+
+// now returns the current time of the configured Clock, so time-dependent
+// behavior can be tested deterministically.
+func (c *Config) now() time.Time {
+	if c.Clock != nil {
+		return c.Clock()
+	}
+	return time.Now()
+}
+
+// random returns a pseudo-random number in [0, 1) of the configured Rand, so
+// randomized behavior can be tested deterministically.
+func (c *Config) random() float64 {
+	if c.Rand != nil {
+		return c.Rand()
+	}
+	return rand.Float64()
+}
+
+// jitter returns a random delay below ReloadJitter.
+func (c *Config) jitter() time.Duration {
+	if c.ReloadJitter <= 0 {
+		return 0
+	}
+	return time.Duration(c.random() * float64(c.ReloadJitter))
+}
+
+// withClock returns the loader reading the time from the Clock of the
+// Config: a copy of a CacheLoader without Clock of its own, or the loader
+// itself.
+func (c *Config) withClock(loader Loader) Loader {
+	if cache, ok := loader.(*CacheLoader); ok && cache.Clock == nil {
+		withClock := *cache
+		withClock.Clock = c.now
+		return &withClock
+	}
+	return loader
+}
+
+// sleep waits for d with the configured After timer, reporting false if stop
+// is closed first.
+func (c *Config) sleep(d time.Duration, stop <-chan struct{}) bool {
+	if c.After != nil {
+		select {
+		case <-c.After(d):
+			return true
+		case <-stop:
+			return false
+		}
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-stop:
+		return false
+	}
+}
@@ -0,0 +1,44 @@
+package echoi18n
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestConfig_now tests the pluggable clock.
+func TestConfig_now(t *testing.T) {
+	t.Parallel()
+	fixed := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, fixed, (&Config{Clock: func() time.Time { return fixed }}).now())
+	assert.WithinDuration(t, time.Now(), (&Config{}).now(), time.Minute)
+}
+
+// TestConfig_jitter tests the random delay added to the polls.
+func TestConfig_jitter(t *testing.T) {
+	t.Parallel()
+	half := func() float64 { return 0.5 }
+	assert.Zero(t, (&Config{Rand: half}).jitter())
+	assert.Equal(t, 30*time.Second, (&Config{ReloadJitter: time.Minute, Rand: half}).jitter())
+	assert.Less(t, (&Config{ReloadJitter: time.Minute}).jitter(), time.Minute)
+}
+
+// TestConfig_withClock tests reading the time of a CacheLoader from the Clock
+// of the Config using it.
+func TestConfig_withClock(t *testing.T) {
+	t.Parallel()
+	fixed := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	cache := &CacheLoader{Dir: t.TempDir()}
+	cfg := configDefault(&Config{
+		Loader: cache,
+		Layers: []Layer{{Loader: cache}},
+		Clock:  func() time.Time { return fixed },
+	})
+	assert.Equal(t, fixed, cfg.Loader.(*CacheLoader).now())
+	assert.Equal(t, fixed, cfg.Layers[0].Loader.(*CacheLoader).now())
+	assert.Nil(t, cache.Clock)
+
+	own := &CacheLoader{Clock: time.Now}
+	assert.Same(t, own, cfg.withClock(own))
+}
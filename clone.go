@@ -0,0 +1,70 @@
+package echoi18n
+
+import "reflect"
+
+// Clone returns a deep copy of the settings of the Config, without the
+// catalog loaded by a middleware, so a Config can serve as a template for
+// several servers. Slices, maps, pointers and structs of the settings are
+// copied with their elements, e.g. the messages of Bundles and the Layers.
+// Values held by interfaces and functions, such as the Loader of the Config
+// and of its Layers, the Cache or the Funcs, are services and are shared.
+func (c *Config) Clone() *Config {
+	clone := &Config{}
+	src, dst := reflect.ValueOf(c).Elem(), reflect.ValueOf(clone).Elem()
+	for i := 0; i < src.NumField(); i++ {
+		if src.Type().Field(i).IsExported() {
+			dst.Field(i).Set(deepCopy(src.Field(i)))
+		}
+	}
+	return clone
+}
+
+// deepCopy returns a copy of v with its slices, maps, pointers and exported
+// struct fields copied recursively. Map keys, interfaces and functions are
+// returned as is.
+func deepCopy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			copied.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return copied
+	case reflect.Array:
+		copied := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			copied.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return copied
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			copied.SetMapIndex(iter.Key(), deepCopy(iter.Value()))
+		}
+		return copied
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.New(v.Type().Elem())
+		copied.Elem().Set(deepCopy(v.Elem()))
+		return copied
+	case reflect.Struct:
+		copied := reflect.New(v.Type()).Elem()
+		copied.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if field := copied.Field(i); field.CanSet() {
+				field.Set(deepCopy(v.Field(i)))
+			}
+		}
+		return copied
+	}
+	return v
+}
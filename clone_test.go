@@ -0,0 +1,63 @@
+package echoi18n
+
+import (
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// TestConfig_Clone tests copying the settings of a Config.
+func TestConfig_Clone(t *testing.T) {
+	t.Parallel()
+	template := &Config{
+		AcceptLanguages: []language.Tag{language.English, language.German},
+		DomainLanguages: map[string]string{".de": "de"},
+		CookieName:      "locale",
+		Bundles:         []Bundle{{"en": {{ID: "welcome", Other: "hello"}}}},
+		Layers:          []Layer{{Name: "overrides", RefreshInterval: time.Minute}},
+	}
+	clone := template.Clone()
+	assert.Equal(t, template, clone)
+
+	clone.AcceptLanguages[1] = language.French
+	clone.DomainLanguages[".fr"] = "fr"
+	clone.CookieName = "lang"
+	clone.Bundles[0]["en"][0].Other = "hi"
+	clone.Bundles[0]["de"] = []*i18n.Message{{ID: "welcome", Other: "hallo"}}
+	clone.Layers[0].Name = "hotfixes"
+	assert.Equal(t, []language.Tag{language.English, language.German}, template.AcceptLanguages)
+	assert.Equal(t, map[string]string{".de": "de"}, template.DomainLanguages)
+	assert.Equal(t, "locale", template.CookieName)
+	assert.Equal(t, []Bundle{{"en": {{ID: "welcome", Other: "hello"}}}}, template.Bundles)
+	assert.Equal(t, "overrides", template.Layers[0].Name)
+}
+
+// TestNewMiddleware_immutable tests that the middleware copies its configuration.
+func TestNewMiddleware_immutable(t *testing.T) {
+	t.Parallel()
+	NewMiddleware()
+	assert.Nil(t, ConfigDefault.current.Load())
+
+	cfg := &Config{}
+	app := echo.New()
+	app.Use(NewMiddleware(cfg))
+	app.GET("/", func(c echo.Context) error {
+		return c.String(http.StatusOK, MustLocalize(c, "welcome"))
+	})
+	assert.Equal(t, language.Und, cfg.DefaultLanguage)
+	assert.Nil(t, cfg.LangHandler)
+	assert.Equal(t, "/en/about", cfg.LocalizedPath("en", "about"))
+	assert.Equal(t, "en", cfg.Stats().DefaultLanguage)
+
+	cfg.DefaultLanguage = language.Chinese
+	got, err := makeRequest(language.Und, "", app)
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(got.Body)
+	assert.Equal(t, "hello", string(body))
+}
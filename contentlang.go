@@ -0,0 +1,35 @@
+package echoi18n
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// HeaderContentLanguage is the response header naming the language of the
+// response.
+const HeaderContentLanguage = "Content-Language"
+
+// setLanguageHeaders sets the Content-Language of the response to the request
+// language, unless the handler set one, and adds Accept-Language to its Vary
+// header, for HTTP caches and search engines.
+func (c *Config) setLanguageHeaders(ctx echo.Context) {
+	header := ctx.Response().Header()
+	if header.Get(HeaderContentLanguage) == "" {
+		header.Set(HeaderContentLanguage, c.language(ctx))
+	}
+	addVary(header, "Accept-Language")
+}
+
+// addVary adds a request header to the Vary header unless it is listed.
+func addVary(header http.Header, name string) {
+	for _, value := range header.Values(echo.HeaderVary) {
+		for _, field := range strings.Split(value, ",") {
+			if field = strings.TrimSpace(field); strings.EqualFold(field, name) || field == "*" {
+				return
+			}
+		}
+	}
+	header.Add(echo.HeaderVary, name)
+}
@@ -0,0 +1,55 @@
+package echoi18n
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestConfig_setLanguageHeaders tests the Content-Language and Vary headers
+// of the responses.
+func TestConfig_setLanguageHeaders(t *testing.T) {
+	t.Parallel()
+	newApp := func(cfg *Config) *echo.Echo {
+		app := echo.New()
+		app.Use(NewMiddleware(cfg))
+		app.GET("/", func(c echo.Context) error {
+			if vary := c.QueryParam("vary"); vary != "" {
+				c.Response().Header().Add(echo.HeaderVary, vary)
+			}
+			if lang := c.QueryParam("content"); lang != "" {
+				c.Response().Header().Set(HeaderContentLanguage, lang)
+			}
+			return c.NoContent(http.StatusOK)
+		})
+		return app
+	}
+	app := newApp(&Config{})
+	disabled := newApp(&Config{NoLangHeaders: true})
+
+	tests := []struct {
+		name        string
+		app         *echo.Echo
+		url         string
+		wantContent string
+		wantVary    []string
+	}{
+		{"negotiated", app, "/?lang=zh", "zh", []string{"Accept-Language"}},
+		{"default", app, "/", "en", []string{"Accept-Language"}},
+		{"handler language", app, "/?lang=zh&content=zh-Hans", "zh-Hans", []string{"Accept-Language"}},
+		{"listed vary", app, "/?vary=Cookie,+accept-language", "en", []string{"Cookie, accept-language"}},
+		{"other vary", app, "/?vary=Cookie", "en", []string{"Cookie", "Accept-Language"}},
+		{"disabled", disabled, "/?lang=zh", "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			tt.app.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.url, nil))
+			assert.Equal(t, tt.wantContent, rec.Header().Get(HeaderContentLanguage))
+			assert.Equal(t, tt.wantVary, rec.Header().Values(echo.HeaderVary))
+		})
+	}
+}
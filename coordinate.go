@@ -0,0 +1,35 @@
+package echoi18n
+
+// Coordinator broadcasts the catalog versions applied by Reload to the other
+// instances of a fleet, e.g. over Redis pub/sub or NATS, so they reload too
+// and serve the same catalog during rollouts.
+type Coordinator interface {
+	// Publish notifies the peers that the instance applied a catalog version.
+	Publish(version string) error
+	// Subscribe calls reload with each version published by a peer.
+	Subscribe(reload func(version string)) error
+}
+
+// subscribe registers the middleware for the reloads of its peers.
+func (c *Config) subscribe() error {
+	if c.settings.Coordinator == nil {
+		return nil
+	}
+	return c.settings.Coordinator.Subscribe(c.peerReload)
+}
+
+// peerReload reloads the catalog after a peer applied version, unless it is
+// already served. Peer reloads are not published again. A loader serving
+// another version, e.g. while a CDN propagates the new files, is logged.
+func (c *Config) peerReload(version string) {
+	if c.Version() == version {
+		return
+	}
+	if _, err := c.reload(false); err != nil {
+		c.logf("i18n: reload of catalog version %s: %v", version, err)
+		return
+	}
+	if got := c.Version(); got != version {
+		c.logf("i18n: reloaded catalog version %s instead of %s", got, version)
+	}
+}
@@ -0,0 +1,82 @@
+package echoi18n
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// bus is an in-process Coordinator delivering versions to the other instances.
+type bus struct {
+	mu          sync.Mutex
+	subscribers []func(version string)
+	published   []string
+	err         error // Publish error, if any.
+}
+
+// instance returns the Coordinator of a fleet instance.
+func (b *bus) instance() Coordinator {
+	return &busInstance{bus: b}
+}
+
+// busInstance is an instance of a bus.
+type busInstance struct {
+	bus    *bus
+	reload func(version string)
+}
+
+// Publish delivers version to the other instances.
+func (i *busInstance) Publish(version string) error {
+	i.bus.mu.Lock()
+	if i.bus.err != nil {
+		defer i.bus.mu.Unlock()
+		return i.bus.err
+	}
+	i.bus.published = append(i.bus.published, version)
+	subscribers := append([]func(string){}, i.bus.subscribers...)
+	i.bus.mu.Unlock()
+	for _, reload := range subscribers {
+		reload(version)
+	}
+	return nil
+}
+
+// Subscribe registers the instance.
+func (i *busInstance) Subscribe(reload func(version string)) error {
+	i.bus.mu.Lock()
+	defer i.bus.mu.Unlock()
+	i.bus.subscribers = append(i.bus.subscribers, reload)
+	return nil
+}
+
+// TestConfig_Coordinator tests reloading the peers of an instance.
+func TestConfig_Coordinator(t *testing.T) {
+	t.Parallel()
+	loader := &reloadLoader{}
+	loader.set("welcome: v1", nil)
+	b := &bus{}
+	newInstance := func() *Config {
+		cfg := &Config{AcceptLanguages: []language.Tag{language.English}, Loader: loader, RootPath: "localize", Coordinator: b.instance()}
+		NewMiddleware(cfg)
+		return cfg
+	}
+	first, second := newInstance(), newInstance()
+	v1 := first.Version()
+
+	loader.set("welcome: v2", nil)
+	assert.NoError(t, first.Reload())
+	assert.NotEqual(t, v1, first.Version())
+	assert.Equal(t, first.Version(), second.Version())
+	assert.Equal(t, []string{first.Version()}, b.published)
+
+	assert.NoError(t, second.Reload())
+	assert.Len(t, b.published, 1)
+
+	loader.set("welcome: v3", nil)
+	b.err = errors.New("connection refused")
+	assert.EqualError(t, first.Reload(), "i18n.Reload error: connection refused")
+	assert.NotEqual(t, first.Version(), second.Version())
+}
@@ -0,0 +1,48 @@
+package echoi18n
+
+import (
+	"context"
+
+	"github.com/labstack/echo/v4"
+)
+
+// detectTimeoutKey marks in the Echo Context a request whose language
+// detection exceeded DetectTimeout.
+const detectTimeoutKey = "echoi18n.detectTimeout"
+
+// detect runs the LangHandler of the request within DetectTimeout. The
+// deadline is set on the request context for resolvers doing I/O, e.g. a
+// database lookup of the user's language. A detection exceeding it is
+// counted and reported as false, and the later detections of the request
+// are skipped.
+//
+// The LangHandler runs on the request goroutine, as the Echo Context is not
+// safe for concurrent use and is recycled once the request ends: the
+// deadline only bounds the request if the resolvers honor the request
+// context. A resolver ignoring it blocks the request for its full duration,
+// and only then is the default language served.
+func (c *Config) detect(ctx echo.Context, langHandler func(echo.Context, string) string, defaultLang string) (string, bool) {
+	if c.DetectTimeout <= 0 || ctx == nil || ctx.Request() == nil {
+		return langHandler(ctx, defaultLang), true
+	}
+	if timedOut, _ := ctx.Get(detectTimeoutKey).(bool); timedOut {
+		return defaultLang, false
+	}
+	req := ctx.Request()
+	deadline, cancel := context.WithTimeout(req.Context(), c.DetectTimeout)
+	defer cancel()
+	withDeadline := req.WithContext(deadline)
+	ctx.SetRequest(withDeadline)
+	start := c.now()
+	lang := langHandler(ctx, defaultLang)
+	elapsed := c.now().Sub(start)
+	if ctx.Request() == withDeadline {
+		ctx.SetRequest(req)
+	}
+	if elapsed > c.DetectTimeout || deadline.Err() != nil {
+		ctx.Set(detectTimeoutKey, true)
+		c.root().detectTimeouts.Add(1)
+		return defaultLang, false
+	}
+	return lang, true
+}
@@ -0,0 +1,64 @@
+package echoi18n
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestConfig_DetectTimeout tests serving the default language when the
+// language detection exceeds its deadline.
+func TestConfig_DetectTimeout(t *testing.T) {
+	t.Parallel()
+	var calls atomic.Int32
+	cfg := &Config{
+		DetectTimeout: 20 * time.Millisecond,
+		DebugHeaders:  true,
+		Resolvers: []LanguageResolver{ResolverFunc(func(c echo.Context) (string, bool) {
+			calls.Add(1)
+			if _, ok := c.Request().Context().Deadline(); !ok {
+				return "", false
+			}
+			if c.QueryParam("slow") != "" {
+				<-c.Request().Context().Done()
+			}
+			return "zh", true
+		})},
+	}
+	app := echo.New()
+	app.Use(NewMiddleware(cfg))
+	app.GET("/", func(c echo.Context) error {
+		first := MustLocalize(c, "welcome")
+		if _, ok := c.Request().Context().Deadline(); ok {
+			return c.NoContent(http.StatusInternalServerError)
+		}
+		return c.String(http.StatusOK, first+" "+MustLocalize(c, "welcome"))
+	})
+
+	tests := []struct {
+		name       string
+		url        string
+		want       string
+		wantSource string
+		wantCalls  int32
+	}{
+		{"within deadline", "/", "你好 你好", SourceHandler, 1},
+		{"past deadline", "/?slow=1", "hello hello", SourceTimeout, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls.Store(0)
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.url, nil))
+			assert.Equal(t, tt.want, rec.Body.String())
+			assert.Equal(t, tt.wantSource, rec.Header().Get(HeaderSource))
+			assert.Equal(t, tt.wantCalls, calls.Load())
+		})
+	}
+	assert.Equal(t, int64(1), cfg.Stats().DetectTimeouts)
+}
@@ -0,0 +1,99 @@
+package echoi18n
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+// sourceKey is the key of the detector that chose the language in the Echo Context.
+const sourceKey = "echoi18n.source"
+
+// Debug response headers describing the negotiated language.
+const (
+	HeaderLanguage = "X-I18n-Language"
+	HeaderSource   = "X-I18n-Source"
+)
+
+// Sources of the negotiated language reported in the X-I18n-Source header.
+const (
+	SourcePath     = "path"     // The language prefix of the request path.
+	SourceQuery    = "query"    // The lang query parameter.
+	SourceCookie   = "cookie"   // The language cookie.
+	SourceHeader   = "header"   // The Accept-Language header.
+	SourceDefault  = "default"  // The default language.
+	SourceDomain   = "domain"   // The regional default language of the request domain.
+	SourceHandler  = "handler"  // A custom LangHandler.
+	SourceFallback = "fallback" // The default language replacing an unsupported one.
+	SourceOverride = "override" // A language set by SetLanguage.
+	SourceTimeout  = "timeout"  // The default language replacing a detection past DetectTimeout.
+)
+
+// negotiate returns the supported language for the request and the source
+// that chose it, resolved once per request.
+func (c *Config) negotiate(ctx echo.Context) (string, string) {
+	r := c.resolve(ctx)
+	return r.lang, r.source
+}
+
+// negotiateLanguage negotiates the supported language for the request and
+// the source that chose it. A requested language without localizer is
+// replaced by the closest supported one, e.g. "en" for "en-GB", or else by
+// the default language of the request group or domain.
+func (c *Config) negotiateLanguage(ctx echo.Context) (string, string) {
+	trace := detectionTrace(ctx)
+	defer trace.done()
+	if lang, ok := overriddenLanguage(ctx); ok {
+		if trace != nil {
+			trace.addf("override: %s set by SetLanguage", lang)
+		}
+		return lang, SourceOverride
+	}
+	if ctx != nil {
+		ctx.Set(sourceKey, SourceHandler)
+	}
+	defaultLang, langHandler, domain := c.defaultLanguage(ctx)
+	lang, ok := c.detect(ctx, langHandler, defaultLang)
+	if !ok {
+		if trace != nil {
+			trace.addf("timeout: detection exceeded %s", c.DetectTimeout)
+		}
+		return defaultLang, SourceTimeout
+	}
+	source := SourceHandler
+	if ctx != nil {
+		source, _ = ctx.Get(sourceKey).(string)
+	}
+	if source == SourceDefault && domain {
+		source = SourceDomain
+	}
+	if trace != nil {
+		trace.addf("detected: %q (%s)", lang, source)
+	}
+	if _, ok := c.localizerMap.Load(lang); ok && !c.betaHidden(ctx, lang) {
+		if trace != nil {
+			trace.addf("match: %s is supported", lang)
+		}
+		return lang, source
+	}
+	if closest, ok := c.acceptMatcher().match(lang); ok && !c.betaHidden(ctx, closest) {
+		if trace != nil {
+			trace.addf("match: %s is the closest supported language to %q", closest, lang)
+		}
+		return closest, source
+	}
+	if trace != nil {
+		if c.betaHidden(ctx, lang) {
+			trace.addf("match: %s is a beta language not allowed to the request", lang)
+		} else {
+			trace.addf("match: no supported language for %q", lang)
+		}
+	}
+	return defaultLang, SourceFallback
+}
+
+// setDebugHeaders sets the debug headers of the negotiated language.
+func (c *Config) setDebugHeaders(ctx echo.Context) {
+	lang, source := c.negotiate(ctx)
+	header := ctx.Response().Header()
+	header.Set(HeaderLanguage, lang)
+	header.Set(HeaderSource, source)
+}
@@ -0,0 +1,66 @@
+package echoi18n
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDebugHeaders tests the negotiated language debug headers.
+func TestDebugHeaders(t *testing.T) {
+	t.Parallel()
+	app := echo.New()
+	app.Use(NewMiddleware(&Config{DebugHeaders: true}))
+	app.GET("/", func(c echo.Context) error {
+		return c.String(http.StatusOK, MustLocalize(c, "welcome"))
+	})
+	custom := echo.New()
+	custom.Use(NewMiddleware(&Config{
+		DebugHeaders: true,
+		LangHandler: func(c echo.Context, defaultLang string) string {
+			return "zh"
+		},
+	}))
+	custom.GET("/", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	tests := []struct {
+		name       string
+		app        *echo.Echo
+		url        string
+		header     string
+		cookie     string
+		wantLang   string
+		wantSource string
+	}{
+		{"query", app, "/?lang=zh", "en", "", "zh", SourceQuery},
+		{"cookie", app, "/", "en", "zh", "zh", SourceCookie},
+		{"header", app, "/", "zh", "", "zh", SourceHeader},
+		{"default", app, "/", "", "", "en", SourceDefault},
+		{"fallback", app, "/?lang=fr", "", "", "en", SourceFallback},
+		{"handler", custom, "/", "", "", "zh", SourceHandler},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			if tt.header != "" {
+				req.Header.Set("Accept-Language", tt.header)
+			}
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: "lang", Value: tt.cookie})
+			}
+			rec := httptest.NewRecorder()
+			tt.app.ServeHTTP(rec, req)
+			assert.Equal(t, tt.wantLang, rec.Header().Get(HeaderLanguage))
+			assert.Equal(t, tt.wantSource, rec.Header().Get(HeaderSource))
+		})
+	}
+
+	rec := httptest.NewRecorder()
+	NewMiddleware(&Config{})(func(c echo.Context) error { return nil })(app.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec))
+	assert.Empty(t, rec.Header().Get(HeaderLanguage))
+}
@@ -0,0 +1,50 @@
+package echoi18n
+
+import (
+	"strings"
+	texttemplate "text/template"
+)
+
+// isIdentifier reports whether s is a bare placeholder name such as "name".
+func isIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, r := range s {
+		if r != '_' && (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (i == 0 || r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// convertDelims rewrites a message using custom delimiters, e.g. "{name}" or
+// "[[ .name ]]", to Go template syntax. Bare names that are not functions are
+// turned into fields of the template data, so "{name}" becomes "{{.name}}".
+func convertDelims(src, leftDelim, rightDelim string, funcs texttemplate.FuncMap) string {
+	var b strings.Builder
+	for {
+		start := strings.Index(src, leftDelim)
+		if start < 0 {
+			break
+		}
+		end := strings.Index(src[start+len(leftDelim):], rightDelim)
+		if end < 0 {
+			break
+		}
+		b.WriteString(escapeGoDelims(src[:start]))
+		action := strings.TrimSpace(src[start+len(leftDelim) : start+len(leftDelim)+end])
+		if _, isFunc := funcs[action]; isIdentifier(action) && !isFunc {
+			action = "." + action
+		}
+		b.WriteString("{{" + action + "}}")
+		src = src[start+len(leftDelim)+end+len(rightDelim):]
+	}
+	b.WriteString(escapeGoDelims(src))
+	return b.String()
+}
+
+// escapeGoDelims escapes literal Go template delimiters in message text.
+func escapeGoDelims(text string) string {
+	return strings.ReplaceAll(text, "{{", `{{"{{"}}`)
+}
@@ -0,0 +1,67 @@
+package echoi18n
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// Test_convertDelims tests converting custom delimiters to Go templates.
+func Test_convertDelims(t *testing.T) {
+	funcs := template.FuncMap{"upper": strings.ToUpper}
+	tests := []struct {
+		name  string
+		src   string
+		left  string
+		right string
+		want  string
+	}{
+		{"single braces", "hello {name}", "{", "}", "hello {{.name}}"},
+		{"double brackets", "hello [[ .name ]], [[upper .city]]", "[[", "]]", "hello {{.name}}, {{upper .city}}"},
+		{"function name", "{upper}", "{", "}", "{{upper}}"},
+		{"literal go delimiters", "{{x}} [[name]]", "[[", "]]", `{{"{{"}}x}} {{.name}}`},
+		{"unterminated", "hello {name", "{", "}", "hello {name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, convertDelims(tt.src, tt.left, tt.right, funcs))
+		})
+	}
+}
+
+// TestConfig_Delims tests serving messages with custom delimiters.
+func TestConfig_Delims(t *testing.T) {
+	t.Parallel()
+	app := echo.New()
+	app.Use(NewMiddleware(&Config{
+		LeftDelim:  "{",
+		RightDelim: "}",
+		Loader: LoaderFunc(func(path string) ([]byte, error) {
+			return nil, nil
+		}),
+		Bundles: []Bundle{{"en": {
+			{ID: "welcomeWithName", Other: "hello {name}"},
+			{ID: "legacy", Other: "hi <<.name>>", LeftDelim: "<<", RightDelim: ">>"},
+		}}},
+	}))
+	app.GET("/:id", func(c echo.Context) error {
+		return c.String(http.StatusOK, MustLocalize(c, &i18n.LocalizeConfig{
+			MessageID:    c.Param("id"),
+			TemplateData: map[string]string{"name": "alex"},
+		}))
+	})
+
+	for id, want := range map[string]string{"welcomeWithName": "hello alex", "legacy": "hi alex"} {
+		got, err := makeRequest(language.English, id, app)
+		assert.NoError(t, err)
+		body, _ := io.ReadAll(got.Body)
+		assert.Equal(t, want, string(body))
+	}
+}
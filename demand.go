@@ -0,0 +1,84 @@
+package echoi18n
+
+import (
+	"sync"
+
+	"golang.org/x/text/language"
+)
+
+// maxDemandLanguages bounds the languages counted by AcceptAnalytics, so that
+// forged headers cannot grow the tally without limit. Languages first seen
+// past the bound are not counted.
+const maxDemandLanguages = 1000
+
+// LanguageDemand counts the requests asking for a language in their
+// Accept-Language header.
+type LanguageDemand struct {
+	Requests  int  `json:"requests"`  // Requests listing the language.
+	Preferred int  `json:"preferred"` // Requests listing it as their first choice.
+	Supported bool `json:"supported"` // Whether the language is among AcceptLanguages.
+}
+
+// demandCounter tallies the languages of the Accept-Language headers. It
+// outlives the catalog snapshots.
+type demandCounter struct {
+	mu     sync.Mutex
+	counts map[string]*LanguageDemand // Counts by language tag.
+}
+
+// newDemandCounter creates an empty demand counter.
+func newDemandCounter() *demandCounter {
+	return &demandCounter{counts: map[string]*LanguageDemand{}}
+}
+
+// record counts the languages of an Accept-Language header, ignoring those
+// of quality 0.
+func (d *demandCounter) record(header string) {
+	tags, qualities, err := language.ParseAcceptLanguage(header)
+	if err != nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for i, tag := range tags {
+		if qualities[i] <= 0 {
+			continue
+		}
+		lang := tag.String()
+		counts, ok := d.counts[lang]
+		if !ok {
+			if len(d.counts) >= maxDemandLanguages {
+				continue
+			}
+			counts = &LanguageDemand{}
+			d.counts[lang] = counts
+		}
+		counts.Requests++
+		if i == 0 {
+			counts.Preferred++
+		}
+	}
+}
+
+// report returns the tally, flagging the supported languages.
+func (d *demandCounter) report(supported map[string]bool) map[string]LanguageDemand {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(d.counts) == 0 {
+		return nil
+	}
+	report := make(map[string]LanguageDemand, len(d.counts))
+	for lang, counts := range d.counts {
+		demand := *counts
+		demand.Supported = supported[lang]
+		report[lang] = demand
+	}
+	return report
+}
+
+// countDemand records the Accept-Language header of a request.
+func (c *Config) countDemand(header string) {
+	if demand := c.root().demand; demand != nil && header != "" {
+		demand.record(header)
+	}
+}
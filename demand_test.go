@@ -0,0 +1,49 @@
+package echoi18n
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestConfig_AcceptAnalytics tests counting the languages requested by the
+// Accept-Language headers.
+func TestConfig_AcceptAnalytics(t *testing.T) {
+	t.Parallel()
+	cfg := &Config{AcceptAnalytics: true}
+	app := echo.New()
+	app.Use(NewMiddleware(cfg))
+	app.GET("/", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	for _, header := range []string{"fr-CA, fr;q=0.9, en;q=0.5", "fr", "zh, ja;q=0", "", "invalid;q=x"} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Accept-Language", header)
+		app.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	assert.Equal(t, map[string]LanguageDemand{
+		"fr-CA": {Requests: 1, Preferred: 1},
+		"fr":    {Requests: 2, Preferred: 1},
+		"en":    {Requests: 1, Supported: true},
+		"zh":    {Requests: 1, Preferred: 1, Supported: true},
+	}, cfg.Stats().Demand)
+}
+
+// TestDemandCounter tests bounding the counted languages.
+func TestDemandCounter(t *testing.T) {
+	t.Parallel()
+	demand := newDemandCounter()
+	for i := 0; i < maxDemandLanguages; i++ {
+		demand.counts[fmt.Sprintf("x-%d", i)] = &LanguageDemand{}
+	}
+	demand.record("fr")
+	_, ok := demand.counts["fr"]
+	assert.False(t, ok)
+	assert.Len(t, demand.counts, maxDemandLanguages)
+}
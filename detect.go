@@ -0,0 +1,50 @@
+package echoi18n
+
+import (
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// queryParam returns the first value of a query parameter. Unescaped values
+// are sliced from the raw query without allocating; escaped ones fall back to
+// the parsed query of the context.
+func queryParam(c echo.Context, name string) string {
+	rawQuery := c.Request().URL.RawQuery
+	for rawQuery != "" {
+		var pair string
+		pair, rawQuery, _ = strings.Cut(rawQuery, "&")
+		key, value, _ := strings.Cut(pair, "=")
+		if strings.ContainsAny(key, "%+") {
+			return c.QueryParam(name)
+		}
+		if key != name {
+			continue
+		}
+		if strings.ContainsAny(value, "%+") {
+			return c.QueryParam(name)
+		}
+		return value
+	}
+	return ""
+}
+
+// cookieValue returns the value of the first cookie with the given name,
+// scanning the Cookie headers without allocating.
+func cookieValue(c echo.Context, name string) string {
+	for _, line := range c.Request().Header["Cookie"] {
+		for line != "" {
+			var part string
+			part, line, _ = strings.Cut(line, ";")
+			key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+			if !ok || key != name {
+				continue
+			}
+			if len(value) > 1 && value[0] == '"' && value[len(value)-1] == '"' {
+				value = value[1 : len(value)-1]
+			}
+			return value
+		}
+	}
+	return ""
+}
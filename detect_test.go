@@ -0,0 +1,97 @@
+package echoi18n
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// detectRequests are requests selecting a language by each detector.
+var detectRequests = []struct {
+	name   string
+	url    string
+	header string
+	cookie string
+	want   string
+}{
+	{"query", "/?page=2&lang=zh", "", "", "zh"},
+	{"escaped query", "/?lang=zh%2DHant", "", "", "zh-Hant"},
+	{"escaped key", "/?l%61ng=zh", "", "", "zh"},
+	{"cookie", "/", "", `theme=dark; lang="zh"`, "zh"},
+	{"header", "/", "zh", "", "zh"},
+	{"weighted header", "/", "en-US,en;q=0.9,zh;q=0.8", "", "en"},
+	{"default", "/?language=zh", "", "language=zh", "en"},
+}
+
+// newDetectContext returns a context of a detectRequests request.
+func newDetectContext(url, header, cookie string) (echo.Context, *http.Request, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(http.MethodGet, url, nil)
+	if header != "" {
+		req.Header.Set("Accept-Language", header)
+	}
+	if cookie != "" {
+		req.Header.Set("Cookie", cookie)
+	}
+	rec := httptest.NewRecorder()
+	return echo.New().NewContext(req, rec), req, rec
+}
+
+// Test_queryParam_cookieValue tests the allocation-free detectors of the default language handler.
+func Test_queryParam_cookieValue(t *testing.T) {
+	for _, tt := range detectRequests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _, _ := newDetectContext(tt.url, tt.header, tt.cookie)
+			assert.Equal(t, tt.want, defaultLangHandler(c, "en"))
+		})
+	}
+}
+
+// TestConfig_language_allocs tests that negotiating unescaped languages does
+// not allocate beyond the middleware setup of the request.
+func TestConfig_language_allocs(t *testing.T) {
+	cfg := &Config{}
+	NewMiddleware(cfg)
+	snapshot := cfg.active()
+	for _, tt := range detectRequests {
+		if tt.name == "escaped query" || tt.name == "escaped key" || tt.name == "weighted header" {
+			continue
+		}
+		t.Run(tt.name, func(t *testing.T) {
+			c, req, rec := newDetectContext(tt.url, tt.header, tt.cookie)
+			setup := testing.AllocsPerRun(100, func() {
+				c.Reset(req, rec)
+				c.Set(localsKey, &resolution{catalog: snapshot})
+			})
+			var lang string
+			negotiate := testing.AllocsPerRun(100, func() {
+				c.Reset(req, rec)
+				c.Set(localsKey, &resolution{catalog: snapshot})
+				lang = snapshot.language(c)
+				snapshot.language(c)
+			})
+			assert.Equal(t, tt.want, lang)
+			assert.Equal(t, setup, negotiate)
+		})
+	}
+}
+
+// BenchmarkConfig_language measures negotiating the language of a request.
+func BenchmarkConfig_language(b *testing.B) {
+	cfg := &Config{}
+	NewMiddleware(cfg)
+	snapshot := cfg.active()
+	for _, tt := range detectRequests {
+		b.Run(tt.name, func(b *testing.B) {
+			c, req, rec := newDetectContext(tt.url, tt.header, tt.cookie)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				c.Reset(req, rec)
+				c.Set(localsKey, &resolution{catalog: snapshot})
+				snapshot.language(c)
+			}
+		})
+	}
+}
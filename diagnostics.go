@@ -0,0 +1,86 @@
+package echoi18n
+
+import "sort"
+
+// Severity is the level of a load diagnostic.
+type Severity string
+
+// Severities of load diagnostics.
+const (
+	SeverityError   Severity = "error"   // The catalog cannot be served.
+	SeverityWarning Severity = "warning" // The catalog is served but likely wrong.
+	SeverityInfo    Severity = "info"    // Informational load event.
+)
+
+// Diagnostic is an event or problem found while loading the catalog.
+type Diagnostic struct {
+	Severity  Severity `json:"severity"`
+	File      string   `json:"file,omitempty"` // Path of the message file passed to the Loader.
+	Lang      string   `json:"lang,omitempty"`
+	MessageID string   `json:"id,omitempty"`
+	Message   string   `json:"message"`
+	Err       error    `json:"-"` // Underlying error of a load failure, nil for the other diagnostics.
+}
+
+// Error returns the diagnostic message.
+func (d Diagnostic) Error() string {
+	return d.Message
+}
+
+// Unwrap returns the underlying error of a load failure.
+func (d Diagnostic) Unwrap() error {
+	return d.Err
+}
+
+// Diagnostics is the machine-readable load health report of the catalog.
+type Diagnostics []Diagnostic
+
+// Filter returns the diagnostics of the severity.
+func (d Diagnostics) Filter(severity Severity) Diagnostics {
+	var filtered Diagnostics
+	for _, diagnostic := range d {
+		if diagnostic.Severity == severity {
+			filtered = append(filtered, diagnostic)
+		}
+	}
+	return filtered
+}
+
+// Err returns the first error diagnostic, or nil if there is none.
+func (d Diagnostics) Err() error {
+	for _, diagnostic := range d {
+		if diagnostic.Severity == SeverityError {
+			return diagnostic
+		}
+	}
+	return nil
+}
+
+// Diagnostics returns the report of the last catalog load.
+func (c *Config) Diagnostics() Diagnostics {
+	return c.active().diagnostics
+}
+
+// load loads and validates the catalog, stopping before validation when
+// message files fail to load.
+func (c *Config) load() Diagnostics {
+	diagnostics := c.loadMessages()
+	if diagnostics.Err() != nil {
+		return diagnostics
+	}
+	if !c.LazyTemplates {
+		diagnostics = append(diagnostics, c.validateTemplates()...)
+	}
+	diagnostics = append(diagnostics, c.validatePlaceholders()...)
+	return append(diagnostics, c.checkTerms()...)
+}
+
+// catalogLanguages returns the loaded languages in order.
+func (c *Config) catalogLanguages() []string {
+	langs := make([]string, 0, len(c.messages))
+	for lang := range c.messages {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
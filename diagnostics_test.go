@@ -0,0 +1,65 @@
+package echoi18n
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// diagnosticsLoader loads the message files of the diagnostics tests.
+var diagnosticsLoader = LoaderFunc(func(path string) ([]byte, error) {
+	switch path {
+	case "localize/en.yaml":
+		return []byte("greeting: Hello {{ .name }}\nbrand: Try Acme"), nil
+	case "localize/de.yaml":
+		return []byte("greeting: Hallo {{ .nom }}\nbrand: Probiere Akme"), nil
+	}
+	return nil, os.ErrNotExist
+})
+
+// TestConfig_Diagnostics tests the severity-leveled load report.
+func TestConfig_Diagnostics(t *testing.T) {
+	t.Parallel()
+	cfg := &Config{
+		AcceptLanguages: []language.Tag{language.English, language.German},
+		Loader:          diagnosticsLoader,
+		RootPath:        "localize",
+		Variants:        []string{"inclusive"},
+		ProtectedTerms:  map[string]string{"brand": "Acme"},
+		TermAltered:     func(lang, id, term string) {},
+	}
+	NewMiddleware(cfg)
+
+	diagnostics := cfg.Diagnostics()
+	assert.NoError(t, diagnostics.Err())
+	assert.Equal(t, Diagnostics{
+		{Severity: SeverityInfo, File: "localize/en.yaml", Lang: "en", Message: "loaded 2 messages"},
+		{Severity: SeverityInfo, File: "localize/en-x-inclusive.yaml", Lang: "en", Message: "file not found, skipped"},
+		{Severity: SeverityInfo, File: "localize/de.yaml", Lang: "de", Message: "loaded 2 messages"},
+		{Severity: SeverityInfo, File: "localize/de-x-inclusive.yaml", Lang: "de", Message: "file not found, skipped"},
+	}, diagnostics.Filter(SeverityInfo))
+	assert.Equal(t, Diagnostics{
+		{Severity: SeverityWarning, Lang: "de", Message: `placeholders of ["greeting"] in language "de" differ from the default language`},
+		{Severity: SeverityWarning, Lang: "de", MessageID: "brand", Message: `message "brand" in language "de" alters protected term "Acme"`},
+	}, diagnostics.Filter(SeverityWarning))
+	assert.Equal(t, diagnostics, cfg.Stats().Diagnostics)
+}
+
+// TestDiagnostics_Err tests reporting the first error diagnostic.
+func TestDiagnostics_Err(t *testing.T) {
+	t.Parallel()
+	diagnostics := Diagnostics{
+		{Severity: SeverityInfo, Message: "loaded 2 messages"},
+		{Severity: SeverityError, File: "zh.yaml", Lang: "zh", Message: "file does not exist"},
+		{Severity: SeverityError, Message: "second"},
+	}
+	assert.EqualError(t, diagnostics.Err(), "file does not exist")
+	assert.NoError(t, diagnostics[:1].Err())
+
+	data, err := json.Marshal(diagnostics[1])
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"severity": "error", "file": "zh.yaml", "lang": "zh", "message": "file does not exist"}`, string(data))
+}
@@ -0,0 +1,126 @@
+package echoi18n
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// defaultVersionHistory is the number of catalog versions kept for Diff by default.
+const defaultVersionHistory = 10
+
+// LanguageDiff lists the message IDs of a language that changed between two
+// catalog versions.
+type LanguageDiff struct {
+	Added   []string `json:"added,omitempty"`   // IDs only in the new version.
+	Removed []string `json:"removed,omitempty"` // IDs only in the old version.
+	Changed []string `json:"changed,omitempty"` // IDs whose message changed.
+}
+
+// catalogHistory keeps the messages of the last published catalog versions.
+type catalogHistory struct {
+	mu       sync.Mutex
+	versions []string          // Versions, oldest first.
+	catalogs map[string]Bundle // Messages by version.
+}
+
+// record keeps the messages of a published version, forgetting the oldest
+// versions beyond max.
+func (h *catalogHistory) record(version string, messages Bundle, max int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.catalogs == nil {
+		h.catalogs = map[string]Bundle{}
+	}
+	if _, ok := h.catalogs[version]; ok {
+		return
+	}
+	h.versions = append(h.versions, version)
+	h.catalogs[version] = messages
+	for len(h.versions) > max {
+		delete(h.catalogs, h.versions[0])
+		h.versions = h.versions[1:]
+	}
+}
+
+// catalog returns the messages of a kept version.
+func (h *catalogHistory) catalog(version string) (Bundle, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	messages, ok := h.catalogs[version]
+	return messages, ok
+}
+
+// store publishes a snapshot and records its catalog version for Diff.
+func (c *Config) store(snapshot *Config) {
+	root := c.root()
+	root.current.Store(snapshot)
+	max := root.settings.VersionHistory
+	if max <= 0 {
+		max = defaultVersionHistory
+	}
+	root.history.record(snapshot.version, snapshot.messages, max)
+}
+
+// Versions returns the catalog versions kept for Diff, oldest first.
+func (c *Config) Versions() []string {
+	history := &c.root().history
+	history.mu.Lock()
+	defer history.mu.Unlock()
+	return append([]string(nil), history.versions...)
+}
+
+// Diff returns the message IDs added, removed and changed in each language
+// from the catalog version oldVersion to newVersion, both among Versions.
+// Languages without changes are omitted.
+func (c *Config) Diff(oldVersion, newVersion string) (map[string]LanguageDiff, error) {
+	history := &c.root().history
+	oldCatalog, ok := history.catalog(oldVersion)
+	if !ok {
+		return nil, fmt.Errorf("i18n.Diff error: unknown catalog version %q", oldVersion)
+	}
+	newCatalog, ok := history.catalog(newVersion)
+	if !ok {
+		return nil, fmt.Errorf("i18n.Diff error: unknown catalog version %q", newVersion)
+	}
+
+	langs := map[string]bool{}
+	for lang := range oldCatalog {
+		langs[lang] = true
+	}
+	for lang := range newCatalog {
+		langs[lang] = true
+	}
+	diffs := map[string]LanguageDiff{}
+	for lang := range langs {
+		if diff := diffMessages(indexMessages(oldCatalog[lang]), indexMessages(newCatalog[lang])); diff.Added != nil || diff.Removed != nil || diff.Changed != nil {
+			diffs[lang] = diff
+		}
+	}
+	return diffs, nil
+}
+
+// diffMessages compares the messages of a language in two catalogs.
+func diffMessages(oldMessages, newMessages map[string]*i18n.Message) LanguageDiff {
+	var diff LanguageDiff
+	for id, m := range newMessages {
+		old, ok := oldMessages[id]
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, id)
+		case newCatalogMessage(old) != newCatalogMessage(m):
+			diff.Changed = append(diff.Changed, id)
+		}
+	}
+	for id := range oldMessages {
+		if _, ok := newMessages[id]; !ok {
+			diff.Removed = append(diff.Removed, id)
+		}
+	}
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Removed)
+	sort.Strings(diff.Changed)
+	return diff
+}
@@ -0,0 +1,52 @@
+package echoi18n
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// TestConfig_Diff tests comparing the messages of two catalog versions.
+func TestConfig_Diff(t *testing.T) {
+	t.Parallel()
+	loader := &reloadLoader{}
+	loader.set("welcome: hello\nbye: goodbye", nil)
+	cfg := &Config{AcceptLanguages: []language.Tag{language.English}, Loader: loader, RootPath: "localize", VersionHistory: 2}
+	app := echo.New()
+	app.Use(NewMiddleware(cfg))
+	RegisterAdmin(app.Group("/admin"))
+	v1 := cfg.Version()
+
+	loader.set("welcome: hi\nhelp: Help", nil)
+	assert.NoError(t, cfg.Reload())
+	v2 := cfg.Version()
+	assert.Equal(t, []string{v1, v2}, cfg.Versions())
+
+	diff, err := cfg.Diff(v1, v2)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]LanguageDiff{
+		"en": {Added: []string{"help"}, Removed: []string{"bye"}, Changed: []string{"welcome"}},
+	}, diff)
+
+	diff, err = cfg.Diff(v2, v2)
+	assert.NoError(t, err)
+	assert.Empty(t, diff)
+
+	got, err := makeRequest(language.Und, "admin/diff/"+v2+"/"+v1, app)
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(got.Body)
+	assert.JSONEq(t, `{"en": {"added": ["bye"], "removed": ["help"], "changed": ["welcome"]}}`, string(body))
+
+	loader.set("welcome: hey", nil)
+	assert.NoError(t, cfg.Reload())
+	_, err = cfg.Diff(v1, cfg.Version())
+	assert.EqualError(t, err, `i18n.Diff error: unknown catalog version "`+v1+`"`)
+
+	got, err = makeRequest(language.Und, "admin/diff/"+v1+"/"+v2, app)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, got.StatusCode)
+}
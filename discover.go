@@ -0,0 +1,71 @@
+package echoi18n
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// discoverLanguages adds the languages of the <lang>.<format> message files
+// found in RootPath to AcceptLanguages, after the configured ones, along with
+// those of the <lang>/ directories with LanguageDirs. File names must be
+// canonical language tags; variant catalogs and other files are ignored.
+func (c *Config) discoverLanguages() Diagnostics {
+	if !c.AutoLanguages {
+		return nil
+	}
+	lister, ok := c.Loader.(FileLister)
+	if !ok {
+		return Diagnostics{{Severity: SeverityError, File: c.RootPath, Message: fmt.Sprintf("Loader %T cannot list message directories", c.Loader)}}
+	}
+	root := path.Clean(c.RootPath)
+	paths, err := lister.ListMessages(root)
+	if err != nil {
+		return Diagnostics{{Severity: SeverityError, File: c.RootPath, Message: err.Error()}}
+	}
+
+	known := make(map[string]bool, len(c.AcceptLanguages))
+	for _, tag := range c.AcceptLanguages {
+		known[tag.String()] = true
+	}
+	var discovered []string
+	for _, p := range paths {
+		rel := strings.TrimPrefix(p, root+"/")
+		name, isDir := rel, false
+		if dir, _, ok := strings.Cut(rel, "/"); ok {
+			if !c.LanguageDirs {
+				continue
+			}
+			name, isDir = dir, true
+		}
+		if !isDir {
+			if !strings.HasPrefix(name, c.FilePrefix) {
+				continue
+			}
+			name = strings.TrimPrefix(name, c.FilePrefix)
+			name = strings.TrimSuffix(name, path.Ext(name))
+		}
+		if known[name] || strings.Contains(name, "-x-") || path.Ext(p) != "."+c.languageFormat(name) {
+			continue
+		}
+		if tag, err := language.Parse(name); err != nil || tag.String() != name {
+			continue
+		}
+		known[name] = true
+		discovered = append(discovered, name)
+	}
+
+	sort.Strings(discovered)
+	langs := make([]language.Tag, len(c.AcceptLanguages), len(c.AcceptLanguages)+len(discovered))
+	copy(langs, c.AcceptLanguages)
+	diagnostics := make(Diagnostics, len(discovered))
+	for i, lang := range discovered {
+		langs = append(langs, language.Make(lang))
+		diagnostics[i] = Diagnostic{Severity: SeverityInfo, Lang: lang, Message: "language discovered in RootPath"}
+	}
+	c.AcceptLanguages = langs
+	return diagnostics
+}
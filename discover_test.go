@@ -0,0 +1,60 @@
+package echoi18n
+
+import (
+	"io"
+	"net/http"
+	"testing"
+	"testing/fstest"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// TestConfig_AutoLanguages tests discovering the supported languages from
+// the message files of RootPath.
+func TestConfig_AutoLanguages(t *testing.T) {
+	t.Parallel()
+	mapFS := fstest.MapFS{
+		"localize/en.yaml":             {Data: []byte("welcome: hello")},
+		"localize/zh.yaml":             {Data: []byte("welcome: 你好")},
+		"localize/en-x-inclusive.yaml": {Data: []byte("welcome: hi all")},
+		"localize/pt_BR.yaml":          {Data: []byte("welcome: olá")},
+		"localize/de.json":             {Data: []byte(`{"welcome": "hallo"}`)},
+		"localize/README.md":           {Data: []byte("# Messages")},
+		"localize/search/fr.yaml":      {Data: []byte("results: résultats")},
+	}
+	cfg := &Config{Loader: &FSLoader{FS: mapFS}, RootPath: "localize", AutoLanguages: true}
+	app := echo.New()
+	app.Use(NewMiddleware(cfg))
+	app.GET("/", func(c echo.Context) error {
+		return c.String(http.StatusOK, MustLocalize(c, "welcome"))
+	})
+	assert.Equal(t, []language.Tag{language.English, language.Chinese}, cfg.active().AcceptLanguages)
+
+	mapFS["localize/fr.yaml"] = &fstest.MapFile{Data: []byte("welcome: bonjour")}
+	assert.NoError(t, cfg.Reload())
+	assert.Equal(t, []language.Tag{language.English, language.French, language.Chinese}, cfg.active().AcceptLanguages)
+	assert.Contains(t, cfg.Diagnostics(), Diagnostic{Severity: SeverityInfo, Lang: "fr", Message: "language discovered in RootPath"})
+
+	got, err := makeRequest(language.French, "", app)
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(got.Body)
+	assert.Equal(t, "bonjour", string(body))
+}
+
+// TestConfig_AutoLanguages_configured tests keeping the configured languages
+// first, and discovering language directories.
+func TestConfig_AutoLanguages_configured(t *testing.T) {
+	t.Parallel()
+	cfg := &Config{
+		AcceptLanguages: []language.Tag{language.Chinese},
+		RootPath:        "testdata/langdirs",
+		AutoLanguages:   true,
+		LanguageDirs:    true,
+	}
+	app := echo.New()
+	app.Use(NewMiddleware(cfg))
+	assert.Equal(t, []language.Tag{language.Chinese, language.English}, cfg.active().AcceptLanguages)
+	assert.Equal(t, []language.Tag{language.Chinese}, cfg.AcceptLanguages)
+}
@@ -0,0 +1,86 @@
+package echoi18n
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// cacheEntryPrefix starts the header line of the entries of a CacheLoader.
+const cacheEntryPrefix = "echoi18n-cache"
+
+// CacheLoader caches the message files of a remote Loader on disk, so
+// restarts within TTL do not hit the backend and expired copies are served
+// while the backend is unreachable. Copies are checked against their SHA-256
+// checksum, corrupted ones being loaded again.
+type CacheLoader struct {
+	Loader Loader           // Remote backend.
+	Dir    string           // Cache directory.
+	TTL    time.Duration    // Age after which copies are loaded again, on every load when 0.
+	Clock  func() time.Time // Current time source, the Clock of the Config using it as its Loader or that of a Layer, time.Now by default.
+}
+
+// now returns the current time of the Clock.
+func (l *CacheLoader) now() time.Time {
+	if l.Clock != nil {
+		return l.Clock()
+	}
+	return time.Now()
+}
+
+// encodeCacheEntry prefixes a file with a header line holding its load time
+// and checksum.
+func encodeCacheEntry(buf []byte, loaded time.Time) []byte {
+	sum := sha256.Sum256(buf)
+	header := fmt.Sprintf("%s %d %s\n", cacheEntryPrefix, loaded.UnixNano(), hex.EncodeToString(sum[:]))
+	return append([]byte(header), buf...)
+}
+
+// decodeCacheEntry returns the file of a cache entry and its load time,
+// failing if the entry is corrupted.
+func decodeCacheEntry(entry []byte) ([]byte, time.Time, error) {
+	header, buf, ok := bytes.Cut(entry, []byte("\n"))
+	fields := bytes.Fields(header)
+	if !ok || len(fields) != 3 || string(fields[0]) != cacheEntryPrefix {
+		return nil, time.Time{}, errors.New("invalid cache entry header")
+	}
+	loaded, err := strconv.ParseInt(string(fields[1]), 10, 64)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("invalid cache entry time: %v", err)
+	}
+	sum := sha256.Sum256(buf)
+	if hex.EncodeToString(sum[:]) != string(fields[2]) {
+		return nil, time.Time{}, errors.New("cache entry checksum mismatch")
+	}
+	return buf, time.Unix(0, loaded), nil
+}
+
+// LoadMessage returns the cached copy of a message file if it is intact and
+// younger than TTL, and loads it from the backend otherwise. Expired copies
+// are returned if the backend fails for another reason than a missing file.
+func (l *CacheLoader) LoadMessage(path string) ([]byte, error) {
+	cached, loaded, cacheErr := []byte(nil), time.Time{}, os.ErrNotExist
+	if entry, err := os.ReadFile(cachePath(l.Dir, path)); err == nil {
+		cached, loaded, cacheErr = decodeCacheEntry(entry)
+	}
+	now := l.now()
+	if cacheErr == nil && now.Sub(loaded) < l.TTL {
+		return cached, nil
+	}
+
+	buf, err := l.Loader.LoadMessage(path)
+	if err == nil {
+		// A copy that cannot be cached is loaded from the backend next time.
+		_ = writeCache(l.Dir, path, encodeCacheEntry(buf, now))
+		return buf, nil
+	}
+	if cacheErr == nil && !errors.Is(err, os.ErrNotExist) {
+		return cached, nil
+	}
+	return nil, err
+}
@@ -0,0 +1,76 @@
+package echoi18n
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCacheLoader tests caching remote message files on disk.
+func TestCacheLoader(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	var loads int
+	var backendErr error
+	loader := &CacheLoader{
+		Loader: LoaderFunc(func(path string) ([]byte, error) {
+			loads++
+			if backendErr != nil {
+				return nil, backendErr
+			}
+			return []byte("welcome: hello " + path), nil
+		}),
+		Dir:   t.TempDir(),
+		TTL:   time.Hour,
+		Clock: func() time.Time { return now },
+	}
+	load := func() string {
+		buf, err := loader.LoadMessage("en.yaml")
+		assert.NoError(t, err)
+		return string(buf)
+	}
+
+	assert.Equal(t, "welcome: hello en.yaml", load())
+	assert.Equal(t, "welcome: hello en.yaml", load())
+	assert.Equal(t, 1, loads, "fresh copies are served from disk")
+
+	now = now.Add(2 * time.Hour)
+	load()
+	assert.Equal(t, 2, loads, "expired copies are loaded again")
+
+	now = now.Add(2 * time.Hour)
+	backendErr = errors.New("timeout")
+	assert.Equal(t, "welcome: hello en.yaml", load(), "expired copies survive outages")
+	assert.Equal(t, 3, loads)
+
+	backendErr = os.ErrNotExist
+	_, err := loader.LoadMessage("en.yaml")
+	assert.ErrorIs(t, err, os.ErrNotExist, "files removed from the backend are missing")
+
+	backendErr = nil
+	load()
+	assert.NoError(t, os.WriteFile(cachePath(loader.Dir, "en.yaml"), []byte("tampered"), 0o644))
+	load()
+	assert.Equal(t, 6, loads, "corrupted copies are loaded again")
+}
+
+// Test_decodeCacheEntry tests the integrity check of cache entries.
+func Test_decodeCacheEntry(t *testing.T) {
+	t.Parallel()
+	loaded := time.Unix(1700000000, 0)
+	entry := encodeCacheEntry([]byte("welcome: hello"), loaded)
+
+	buf, gotLoaded, err := decodeCacheEntry(entry)
+	assert.NoError(t, err)
+	assert.Equal(t, "welcome: hello", string(buf))
+	assert.True(t, loaded.Equal(gotLoaded))
+
+	entry[len(entry)-1] = 'O'
+	_, _, err = decodeCacheEntry(entry)
+	assert.EqualError(t, err, "cache entry checksum mismatch")
+	_, _, err = decodeCacheEntry([]byte("welcome: hello"))
+	assert.EqualError(t, err, "invalid cache entry header")
+}
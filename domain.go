@@ -0,0 +1,51 @@
+package echoi18n
+
+import (
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// requestHost returns the host of the request without its port.
+func requestHost(ctx echo.Context) string {
+	host := ctx.Request().Host
+	if i := strings.LastIndexByte(host, ':'); i >= 0 && !strings.HasSuffix(host, "]") {
+		host = host[:i]
+	}
+	return strings.ToLower(strings.TrimSuffix(host, "."))
+}
+
+// domainLanguage returns the default language of the request domain and
+// whether it comes from DomainLanguages. The host is looked up first, then
+// its parent domains ("example.de") and TLD (".de").
+func (c *Config) domainLanguage(ctx echo.Context) (string, bool) {
+	if len(c.DomainLanguages) == 0 || ctx == nil || ctx.Request() == nil {
+		return c.DefaultLanguage.String(), false
+	}
+	host := requestHost(ctx)
+	if lang, ok := c.supportedDomainLanguage(host); ok {
+		return lang, true
+	}
+	for i := 0; i < len(host); i++ {
+		if host[i] != '.' {
+			continue
+		}
+		if lang, ok := c.supportedDomainLanguage(host[i+1:]); ok {
+			return lang, true
+		}
+		if lang, ok := c.supportedDomainLanguage(host[i:]); ok {
+			return lang, true
+		}
+	}
+	return c.DefaultLanguage.String(), false
+}
+
+// supportedDomainLanguage returns the language configured for a domain if it is supported.
+func (c *Config) supportedDomainLanguage(domain string) (string, bool) {
+	lang, ok := c.DomainLanguages[domain]
+	if !ok {
+		return "", false
+	}
+	_, ok = c.localizerMap.Load(lang)
+	return lang, ok
+}
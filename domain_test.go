@@ -0,0 +1,55 @@
+package echoi18n
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// TestConfig_DomainLanguages tests regional default languages per domain.
+func TestConfig_DomainLanguages(t *testing.T) {
+	t.Parallel()
+	app := echo.New()
+	app.Use(NewMiddleware(&Config{
+		AcceptLanguages: []language.Tag{language.English, language.German, language.CanadianFrench, language.Make("en-CA")},
+		Loader:          LoaderFunc(func(path string) ([]byte, error) { return nil, nil }),
+		DomainLanguages: map[string]string{"example.de": "de", ".ca": "fr-CA", "example.fr": "fr"},
+		DebugHeaders:    true,
+	}))
+	app.GET("/", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	tests := []struct {
+		name       string
+		host       string
+		header     string
+		wantLang   string
+		wantSource string
+	}{
+		{"domain", "example.de", "", "de", SourceDomain},
+		{"subdomain with port", "shop.example.de:8080", "", "de", SourceDomain},
+		{"tld", "example.ca", "", "fr-CA", SourceDomain},
+		{"header wins", "example.ca", "en-CA", "en-CA", SourceHeader},
+		{"unsupported header", "example.de", "ja", "de", SourceDomain},
+		{"unsupported domain language", "example.fr", "", "en", SourceDefault},
+		{"unknown domain", "example.com", "", "en", SourceDefault},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Host = tt.host
+			if tt.header != "" {
+				req.Header.Set("Accept-Language", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+			assert.Equal(t, tt.wantLang, rec.Header().Get(HeaderLanguage))
+			assert.Equal(t, tt.wantSource, rec.Header().Get(HeaderSource))
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package echoi18n
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+// DurationStyle is the width of the units of a formatted duration.
+type DurationStyle int
+
+// Duration styles, e.g. "2 hours 30 minutes", "2 hr 30 min" and "2h 30m" in English.
+const (
+	DurationLong DurationStyle = iota
+	DurationShort
+	DurationNarrow
+)
+
+// durationUnit holds the patterns of a unit per plural form, falling back to
+// plural.Other. Patterns contain a single %d verb.
+type durationUnit map[plural.Form]string
+
+// durationFormat is the unit patterns of a language and style, for days,
+// hours, minutes and seconds, and the separator of the components.
+type durationFormat struct {
+	units     [4]durationUnit
+	separator string
+}
+
+// durationFormats are the built-in formats keyed by base language.
+var durationFormats = map[string][3]durationFormat{
+	"en": {
+		{units: [4]durationUnit{
+			{plural.One: "%d day", plural.Other: "%d days"},
+			{plural.One: "%d hour", plural.Other: "%d hours"},
+			{plural.One: "%d minute", plural.Other: "%d minutes"},
+			{plural.One: "%d second", plural.Other: "%d seconds"},
+		}, separator: " "},
+		{units: [4]durationUnit{
+			{plural.One: "%d day", plural.Other: "%d days"},
+			{plural.Other: "%d hr"},
+			{plural.Other: "%d min"},
+			{plural.Other: "%d sec"},
+		}, separator: " "},
+		{units: [4]durationUnit{
+			{plural.Other: "%dd"},
+			{plural.Other: "%dh"},
+			{plural.Other: "%dm"},
+			{plural.Other: "%ds"},
+		}, separator: " "},
+	},
+	"fr": {
+		{units: [4]durationUnit{
+			{plural.One: "%d jour", plural.Other: "%d jours"},
+			{plural.One: "%d heure", plural.Other: "%d heures"},
+			{plural.One: "%d minute", plural.Other: "%d minutes"},
+			{plural.One: "%d seconde", plural.Other: "%d secondes"},
+		}, separator: " "},
+		{units: [4]durationUnit{
+			{plural.Other: "%d j"},
+			{plural.Other: "%d h"},
+			{plural.Other: "%d min"},
+			{plural.Other: "%d s"},
+		}, separator: " "},
+		{units: [4]durationUnit{
+			{plural.Other: "%dj"},
+			{plural.Other: "%dh"},
+			{plural.Other: "%dmin"},
+			{plural.Other: "%ds"},
+		}, separator: " "},
+	},
+	"de": {
+		{units: [4]durationUnit{
+			{plural.One: "%d Tag", plural.Other: "%d Tage"},
+			{plural.One: "%d Stunde", plural.Other: "%d Stunden"},
+			{plural.One: "%d Minute", plural.Other: "%d Minuten"},
+			{plural.One: "%d Sekunde", plural.Other: "%d Sekunden"},
+		}, separator: " "},
+		{units: [4]durationUnit{
+			{plural.Other: "%d Tg."},
+			{plural.Other: "%d Std."},
+			{plural.Other: "%d Min."},
+			{plural.Other: "%d Sek."},
+		}, separator: " "},
+		{units: [4]durationUnit{
+			{plural.Other: "%d T"},
+			{plural.Other: "%d Std."},
+			{plural.Other: "%d Min."},
+			{plural.Other: "%d Sek."},
+		}, separator: " "},
+	},
+	"zh": {
+		{units: [4]durationUnit{
+			{plural.Other: "%d天"},
+			{plural.Other: "%d小时"},
+			{plural.Other: "%d分钟"},
+			{plural.Other: "%d秒钟"},
+		}},
+		{units: [4]durationUnit{
+			{plural.Other: "%d天"},
+			{plural.Other: "%d小时"},
+			{plural.Other: "%d分钟"},
+			{plural.Other: "%d秒"},
+		}},
+		{units: [4]durationUnit{
+			{plural.Other: "%d天"},
+			{plural.Other: "%d小时"},
+			{plural.Other: "%d分"},
+			{plural.Other: "%d秒"},
+		}},
+	},
+}
+
+// format formats n in the pattern of the plural form of n in lang.
+func (u durationUnit) format(lang language.Tag, n int64) string {
+	pattern, ok := u[plural.Cardinal.MatchPlural(lang, int(n), 0, 0, 0, 0)]
+	if !ok {
+		pattern = u[plural.Other]
+	}
+	return fmt.Sprintf(pattern, n)
+}
+
+// formatDuration formats d rounded down to the second in lang, omitting zero
+// components. Languages without a built-in format use English.
+func formatDuration(lang language.Tag, d time.Duration, style DurationStyle) string {
+	base, _ := lang.Base()
+	formats, ok := durationFormats[base.String()]
+	if !ok {
+		lang, formats = language.English, durationFormats["en"]
+	}
+	if style < DurationLong || style > DurationNarrow {
+		style = DurationLong
+	}
+	format := formats[style]
+
+	sign := ""
+	if d < 0 {
+		sign, d = "-", -d
+	}
+	seconds := int64(d / time.Second)
+	values := [4]int64{seconds / 86400, seconds / 3600 % 24, seconds / 60 % 60, seconds % 60}
+	var parts []string
+	for i, value := range values {
+		if value != 0 {
+			parts = append(parts, format.units[i].format(lang, value))
+		}
+	}
+	if len(parts) == 0 {
+		parts = append(parts, format.units[3].format(lang, 0))
+	}
+	return sign + strings.Join(parts, format.separator)
+}
+
+// FormatDuration formats d for the negotiated language with correctly
+// pluralized units, e.g. "2 hours 30 minutes" or "2 h 30 min" in French.
+// Durations are rounded down to the second.
+func FormatDuration(c echo.Context, d time.Duration, style DurationStyle) string {
+	return formatDuration(requestLanguage(c), d, style)
+}
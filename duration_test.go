@@ -0,0 +1,55 @@
+package echoi18n
+
+import (
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// Test_formatDuration tests the built-in duration formats.
+func Test_formatDuration(t *testing.T) {
+	tests := []struct {
+		lang  language.Tag
+		d     time.Duration
+		style DurationStyle
+		want  string
+	}{
+		{language.English, 2*time.Hour + 30*time.Minute, DurationLong, "2 hours 30 minutes"},
+		{language.English, time.Hour + time.Second, DurationLong, "1 hour 1 second"},
+		{language.English, 2*time.Hour + 30*time.Minute, DurationShort, "2 hr 30 min"},
+		{language.English, 26*time.Hour + 500*time.Millisecond, DurationNarrow, "1d 2h"},
+		{language.English, 0, DurationLong, "0 seconds"},
+		{language.English, -90 * time.Second, DurationShort, "-1 min 30 sec"},
+		{language.French, 2*time.Hour + 30*time.Minute, DurationShort, "2 h 30 min"},
+		{language.French, time.Hour, DurationLong, "1 heure"},
+		{language.French, 0, DurationLong, "0 seconde"},
+		{language.German, 3 * time.Minute, DurationLong, "3 Minuten"},
+		{language.Chinese, 2*time.Hour + 30*time.Minute, DurationLong, "2小时30分钟"},
+		{language.Korean, time.Minute, DurationLong, "1 minute"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, formatDuration(tt.lang, tt.d, tt.style))
+		})
+	}
+}
+
+// TestFormatDuration tests durations in the negotiated language.
+func TestFormatDuration(t *testing.T) {
+	t.Parallel()
+	app := echo.New()
+	app.Use(NewMiddleware(&Config{}))
+	app.GET("/", func(c echo.Context) error {
+		return c.String(http.StatusOK, FormatDuration(c, 90*time.Minute, DurationShort))
+	})
+
+	got, err := makeRequest(language.Chinese, "", app)
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(got.Body)
+	assert.Equal(t, "1小时30分钟", string(body))
+}
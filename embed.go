@@ -14,3 +14,8 @@ type EmbedLoader struct {
 func (e *EmbedLoader) LoadMessage(path string) ([]byte, error) {
 	return e.FS.ReadFile(path)
 }
+
+// ListMessages lists the files below a directory of the embedded filesystem.
+func (e *EmbedLoader) ListMessages(dir string) ([]string, error) {
+	return listFS(e.FS, dir)
+}
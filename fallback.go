@@ -0,0 +1,125 @@
+package echoi18n
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+)
+
+// ParentFallback is a FallbackChain trying the parent locales of a language
+// then its base language, e.g. "en-001" then "en" for "en-GB", or "zh-Hant"
+// then "zh" for "zh-TW".
+func ParentFallback(tag language.Tag) []string {
+	var chain []string
+	for parent := tag.Parent(); parent != language.Und; parent = parent.Parent() {
+		chain = append(chain, parent.String())
+	}
+	if base, _ := tag.Base(); base.String() != tag.String() && (len(chain) == 0 || chain[len(chain)-1] != base.String()) {
+		chain = append(chain, base.String())
+	}
+	return chain
+}
+
+// initFallbacks resolves the FallbackChain of each supported language to the
+// loaded languages, ending with the default language.
+func (c *Config) initFallbacks() {
+	if c.FallbackChain == nil {
+		return
+	}
+	defaultLang := c.DefaultLanguage.String()
+	c.fallbacks = make(map[string][]string, len(c.AcceptLanguages))
+	c.chains = &sync.Map{}
+	for _, tag := range c.AcceptLanguages {
+		lang := tag.String()
+		seen := map[string]bool{lang: true}
+		var chain []string
+		for _, fallback := range append(c.FallbackChain(tag), defaultLang) {
+			if _, ok := c.localizerMap.Load(fallback); ok && !seen[fallback] {
+				seen[fallback] = true
+				chain = append(chain, fallback)
+			}
+		}
+		c.fallbacks[lang] = chain
+	}
+}
+
+// localizeFallback localizes a message missing in lang with the first
+// language of its fallback chain that has it. lc is the localize config of
+// the message before namespace resolution and data preparation, which are
+// done again for each fallback language: the Sanitizers and the bidi
+// isolation depend on the language the message is served in. Other errors
+// are returned as is. The fallback used is recorded in the resolution trace
+// of the request.
+//
+// The chain is walked message by message rather than with a go-i18n
+// localizer of several languages: those match their languages once against
+// the bundle, then fall back from the matched language straight to the
+// default language for missing messages, skipping the rest of the chain.
+func (c *Config) localizeFallback(ctx echo.Context, lang string, lc *i18n.LocalizeConfig, err error) (string, error) {
+	var notFound *i18n.MessageNotFoundErr
+	if !errors.As(err, &notFound) {
+		return "", err
+	}
+	localizers, localized, lookupErr := c.fallbackLocalizers(lang, lc)
+	if lookupErr != nil {
+		return "", lookupErr
+	}
+	id, trace := notFound.MessageID, traceOf(ctx)
+	for i, fallback := range c.fallbacks[lang] {
+		message, fallbackErr := localizers[i].Localize(c.prepare(fallback, localized))
+		if !errors.As(fallbackErr, &notFound) {
+			if trace != nil {
+				trace.addf("fallback: %q missing in %s, served in %s", id, lang, fallback)
+			}
+			return message, fallbackErr
+		}
+	}
+	if trace != nil {
+		trace.addf("fallback: %q missing in %s and its fallbacks %v", id, lang, c.fallbacks[lang])
+	}
+	return "", err
+}
+
+// fallbackLocalizers returns the localizers of the fallback chain of lang for
+// a message, from the main catalog or the namespace shard of the message, and
+// the localize config to use with them.
+func (c *Config) fallbackLocalizers(lang string, lc *i18n.LocalizeConfig) ([]*i18n.Localizer, *i18n.LocalizeConfig, error) {
+	if namespace, id, ok := strings.Cut(lc.MessageID, "."); ok && c.isNamespace(namespace) {
+		sh, err := c.shard(namespace)
+		if err != nil {
+			return nil, nil, err
+		}
+		chain, err := c.chainLocalizers(&sh.chains, lang, sh.localizer)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%v in namespace %q", err, namespace)
+		}
+		sharded := *lc
+		sharded.MessageID = id
+		return chain, &sharded, nil
+	}
+	chain, err := c.chainLocalizers(c.chains, lang, c.languageLocalizer)
+	return chain, lc, err
+}
+
+// chainLocalizers returns the localizers of the fallback chain of lang in a
+// catalog, looked up with localizer. The chain is created on first use, or at
+// startup by WarmUp, and cached in chains for the life of the catalog.
+func (c *Config) chainLocalizers(chains *sync.Map, lang string, localizer func(string) (*i18n.Localizer, bool)) ([]*i18n.Localizer, error) {
+	if chain, ok := chains.Load(lang); ok {
+		return chain.([]*i18n.Localizer), nil
+	}
+	chain := make([]*i18n.Localizer, len(c.fallbacks[lang]))
+	for i, fallback := range c.fallbacks[lang] {
+		var ok bool
+		if chain[i], ok = localizer(fallback); !ok {
+			return nil, fmt.Errorf("no localizer for language %q", fallback)
+		}
+	}
+	actual, _ := chains.LoadOrStore(lang, chain)
+	return actual.([]*i18n.Localizer), nil
+}
@@ -0,0 +1,122 @@
+package echoi18n
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// TestConfig_FallbackChain tests localizing missing messages with the
+// fallback chain of the request language.
+func TestConfig_FallbackChain(t *testing.T) {
+	t.Parallel()
+	files := map[string]string{
+		"localize/en.yaml":    "welcome: Welcome\nbye: Bye\nhelp: Help",
+		"localize/zh.yaml":    "welcome: 你好\nbye: 再见",
+		"localize/zh-TW.yaml": "welcome: 歡迎",
+	}
+	newApp := func(chain func(language.Tag) []string) *echo.Echo {
+		app := echo.New()
+		app.Use(NewMiddleware(&Config{
+			AcceptLanguages: []language.Tag{language.English, language.Chinese, language.Make("zh-TW")},
+			RootPath:        "localize",
+			Loader: LoaderFunc(func(path string) ([]byte, error) {
+				return []byte(files[path]), nil
+			}),
+			FallbackChain: chain,
+		}))
+		app.GET("/:id", func(c echo.Context) error {
+			message, err := Localize(c, c.Param("id"))
+			if err != nil {
+				return c.NoContent(http.StatusNotFound)
+			}
+			return c.String(http.StatusOK, message)
+		})
+		return app
+	}
+	app := newApp(ParentFallback)
+	noFallback := newApp(nil)
+
+	tests := []struct {
+		name string
+		app  *echo.Echo
+		lang language.Tag
+		id   string
+		want string
+	}{
+		{"translated", app, language.Make("zh-TW"), "welcome", "歡迎"},
+		{"base language", app, language.Make("zh-TW"), "bye", "再见"},
+		{"default language", app, language.Make("zh-TW"), "help", "Help"},
+		{"missing everywhere", app, language.Make("zh-TW"), "missing", ""},
+		{"no fallback", noFallback, language.Make("zh-TW"), "bye", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := makeRequest(tt.lang, tt.id, tt.app)
+			assert.NoError(t, err)
+			body, _ := io.ReadAll(got.Body)
+			assert.Equal(t, tt.want, string(body))
+		})
+	}
+}
+
+// TestConfig_FallbackChain_templateData tests preparing the template data of
+// a missing message for the fallback language it is served in.
+func TestConfig_FallbackChain_templateData(t *testing.T) {
+	t.Parallel()
+	files := map[string]string{
+		"localize/en.yaml": "greet: Hello {{.name}}",
+		"localize/ar.yaml": "welcome: مرحبا {{.name}}",
+	}
+	app := echo.New()
+	app.Use(NewMiddleware(&Config{
+		AcceptLanguages: []language.Tag{language.English, language.Arabic},
+		RootPath:        "localize",
+		Loader: LoaderFunc(func(path string) ([]byte, error) {
+			return []byte(files[path]), nil
+		}),
+		FallbackChain: func(language.Tag) []string { return nil },
+		BidiIsolate:   true,
+		Sanitizers: []Sanitizer{func(lang, _ string, value interface{}) interface{} {
+			return fmt.Sprintf("%v (%s)", value, lang)
+		}},
+	}))
+	app.GET("/:id", func(c echo.Context) error {
+		return c.String(http.StatusOK, MustLocalize(c, &i18n.LocalizeConfig{
+			MessageID:    c.Param("id"),
+			TemplateData: map[string]string{"name": "Ann"},
+		}))
+	})
+
+	tests := []struct {
+		name string
+		id   string
+		want string
+	}{
+		{"translated", "welcome", "مرحبا \u2068Ann (ar)\u2069"},
+		{"fallback", "greet", "Hello Ann (en)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := makeRequest(language.Arabic, tt.id, app)
+			assert.NoError(t, err)
+			body, _ := io.ReadAll(got.Body)
+			assert.Equal(t, tt.want, string(body))
+		})
+	}
+}
+
+// TestParentFallback tests the parent locales of languages.
+func TestParentFallback(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, []string{"en-001", "en"}, ParentFallback(language.Make("en-GB")))
+	assert.Equal(t, []string{"zh-Hant", "zh"}, ParentFallback(language.Make("zh-TW")))
+	assert.Equal(t, []string{"pt"}, ParentFallback(language.Make("pt-BR")))
+	assert.Empty(t, ParentFallback(language.English))
+}
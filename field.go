@@ -0,0 +1,86 @@
+package echoi18n
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"golang.org/x/text/language"
+)
+
+// fieldKey normalizes a per-language field name, so "title_zh-Hant",
+// "title_zh_hant" and the Go field name TitleZhHant match.
+func fieldKey(name string) string {
+	return strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(name))
+}
+
+// stringFields returns the non-empty string fields of a struct or map keyed by
+// fieldKey. Struct fields are named by their json tag and their Go name.
+func stringFields(v interface{}) map[string]string {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		rv = rv.Elem()
+	}
+	fields := map[string]string{}
+	add := func(name string, value reflect.Value) {
+		for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+			value = value.Elem()
+		}
+		if value.Kind() == reflect.String && value.String() != "" {
+			fields[fieldKey(name)] = value.String()
+		}
+	}
+	switch rv.Kind() {
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return fields
+		}
+		iter := rv.MapRange()
+		for iter.Next() {
+			add(iter.Key().String(), iter.Value())
+		}
+	case reflect.Struct:
+		rt := rv.Type()
+		for i := 0; i < rt.NumField(); i++ {
+			field := rt.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			add(field.Name, rv.Field(i))
+			if name, _, _ := strings.Cut(field.Tag.Get("json"), ","); name != "" && name != "-" {
+				add(name, rv.Field(i))
+			}
+		}
+	}
+	return fields
+}
+
+// fieldLanguages returns the languages to look up in order: the request
+// language and its parents, then the default language and its parents.
+func fieldLanguages(c echo.Context) []language.Tag {
+	appCfg, err := getConfig(c)
+	if err != nil {
+		return nil
+	}
+	var langs []language.Tag
+	for _, tag := range []language.Tag{language.Make(appCfg.language(c)), appCfg.DefaultLanguage} {
+		for ; tag != language.Und; tag = tag.Parent() {
+			langs = append(langs, tag)
+		}
+	}
+	return langs
+}
+
+// LocalizedField returns the pre-translated content of a struct or map with
+// per-language fields, e.g. title_en and title_zh, for the negotiated
+// language, falling back to its parent languages and the default language.
+// Returns "" if no field is set for these languages.
+func LocalizedField(c echo.Context, v interface{}, name string) string {
+	fields := stringFields(v)
+	for _, lang := range fieldLanguages(c) {
+		if value, ok := fields[fieldKey(name+"_"+lang.String())]; ok {
+			return value
+		}
+	}
+	return ""
+}
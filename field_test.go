@@ -0,0 +1,61 @@
+package echoi18n
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// TestLocalizedField tests selecting pre-translated per-language fields.
+func TestLocalizedField(t *testing.T) {
+	t.Parallel()
+	type product struct {
+		TitleEn     string `json:"title_en"`
+		TitleZh     string `json:"title_zh"`
+		NameEn      string
+		NameZhHant  *string
+		Description string `json:"desc_en"`
+	}
+	hant := "產品"
+	values := map[string]interface{}{
+		"struct": &product{TitleEn: "Product", TitleZh: "产品", NameEn: "Widget", NameZhHant: &hant, Description: "A widget"},
+		"map":    map[string]interface{}{"title_en": "Product", "title_zh": "产品", "name_en": "Widget", "name_zh-Hant": "產品", "desc_en": "A widget"},
+	}
+
+	app := echo.New()
+	app.Use(NewMiddleware(&Config{
+		AcceptLanguages: []language.Tag{language.English, language.Chinese, language.TraditionalChinese},
+		Bundles:         []Bundle{{"zh-Hant": {{ID: "welcome", Other: "你好"}}}},
+	}))
+	app.GET("/:value/:field", func(c echo.Context) error {
+		return c.String(http.StatusOK, LocalizedField(c, values[c.Param("value")], c.Param("field")))
+	})
+
+	tests := []struct {
+		name string
+		lang language.Tag
+		url  string
+		want string
+	}{
+		{"struct request language", language.Chinese, "struct/title", "产品"},
+		{"struct no simplified fallback", language.TraditionalChinese, "struct/title", "Product"},
+		{"struct script field", language.TraditionalChinese, "struct/name", "產品"},
+		{"struct default language", language.Chinese, "struct/desc", "A widget"},
+		{"map request language", language.Chinese, "map/title", "产品"},
+		{"map script field", language.TraditionalChinese, "map/name", "產品"},
+		{"map default language", language.Chinese, "map/name", "Widget"},
+		{"missing", language.Chinese, "map/price", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := makeRequest(tt.lang, tt.url, app)
+			assert.NoError(t, err)
+			body, _ := io.ReadAll(got.Body)
+			assert.Equal(t, tt.want, string(body))
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package echoi18n
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"gopkg.in/yaml.v3"
+)
+
+// Format describes a message file format.
+type Format struct {
+	Name       string                            // Format name used in FormatBundleFile.
+	Extensions []string                          // File extensions without the leading dot.
+	Unmarshal  i18n.UnmarshalFunc                // Function to unmarshal message files.
+	Marshal    func(interface{}) ([]byte, error) // Function to marshal generated message files.
+}
+
+// formats is the registry of message file formats, keyed by name and extension.
+var formats = struct {
+	sync.RWMutex
+	byName map[string]Format
+}{byName: map[string]Format{}}
+
+func init() {
+	RegisterFormat(Format{Name: "yaml", Extensions: []string{"yaml", "yml"}, Unmarshal: yaml.Unmarshal, Marshal: yaml.Marshal})
+	RegisterFormat(Format{Name: "json", Extensions: []string{"json"}, Unmarshal: json.Unmarshal, Marshal: marshalJSON})
+}
+
+// RegisterFormat registers a message file format so that FormatBundleFile and
+// file discovery recognize its name and extensions. Registering a format with
+// an existing name or extension replaces it. Formats without Marshal are
+// read-only: TranslateFile and MergeTranslateFile fail for their languages.
+func RegisterFormat(format Format) {
+	formats.Lock()
+	defer formats.Unlock()
+	formats.byName[strings.ToLower(format.Name)] = format
+	for _, ext := range format.Extensions {
+		formats.byName[strings.ToLower(strings.TrimPrefix(ext, "."))] = format
+	}
+}
+
+// lookupFormat returns the registered format with the given name or extension.
+func lookupFormat(name string) (Format, bool) {
+	formats.RLock()
+	defer formats.RUnlock()
+	format, ok := formats.byName[strings.ToLower(strings.TrimPrefix(name, "."))]
+	return format, ok
+}
+
+// languageFormat returns the file format of the message files of a language.
+func (c *Config) languageFormat(lang string) string {
+	if format, ok := c.LanguageFormats[lang]; ok {
+		return format
+	}
+	return c.FormatBundleFile
+}
+
+// marshalFunc returns the function marshaling the message files of a
+// language: MarshalFunc, or the one of its registered format in LanguageFormats.
+func (c *Config) marshalFunc(lang string) func(interface{}) ([]byte, error) {
+	if name, ok := c.LanguageFormats[lang]; ok && name != c.FormatBundleFile {
+		if format, ok := lookupFormat(name); ok && format.Marshal != nil {
+			return format.Marshal
+		}
+	}
+	return c.MarshalFunc
+}
+
+// formatUnmarshalFuncs returns the unmarshal functions of every registered format
+// keyed by name and extension.
+func formatUnmarshalFuncs() map[string]i18n.UnmarshalFunc {
+	formats.RLock()
+	defer formats.RUnlock()
+	funcs := make(map[string]i18n.UnmarshalFunc, len(formats.byName))
+	for key, format := range formats.byName {
+		funcs[key] = format.Unmarshal
+	}
+	return funcs
+}
+
+// marshalJSON marshals generated JSON message files with indentation.
+func marshalJSON(v interface{}) ([]byte, error) {
+	return json.MarshalIndent(v, "", "  ")
+}
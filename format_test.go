@@ -0,0 +1,102 @@
+package echoi18n
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// unmarshalProperties parses "key=value" lines into a map of messages.
+func unmarshalProperties(data []byte, v interface{}) error {
+	messages := map[string]interface{}{}
+	for _, line := range strings.Split(string(data), "\n") {
+		if key, value, ok := strings.Cut(line, "="); ok {
+			messages[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	*v.(*interface{}) = messages
+	return nil
+}
+
+// restoreFormats restores the format registry when the test ends. Tests
+// registering formats do not run in parallel, so the others never see them.
+func restoreFormats(t *testing.T) {
+	formats.RLock()
+	saved := make(map[string]Format, len(formats.byName))
+	for key, format := range formats.byName {
+		saved[key] = format
+	}
+	formats.RUnlock()
+	t.Cleanup(func() {
+		formats.Lock()
+		defer formats.Unlock()
+		formats.byName = saved
+	})
+}
+
+// TestRegisterFormat tests loading message files of a registered format, and
+// failing to generate them without Marshal.
+func TestRegisterFormat(t *testing.T) {
+	restoreFormats(t)
+	RegisterFormat(Format{Name: "properties", Extensions: []string{".props"}, Unmarshal: unmarshalProperties})
+
+	format, ok := lookupFormat("props")
+	assert.True(t, ok)
+	assert.Equal(t, "properties", format.Name)
+
+	cfg := &Config{
+		FormatBundleFile: "props",
+		Loader: LoaderFunc(func(path string) ([]byte, error) {
+			return map[string][]byte{
+				"example/localize/en.props": []byte("welcome = hello"),
+				"example/localize/zh.props": []byte("welcome = 你好"),
+			}[path], nil
+		}),
+	}
+	app := echo.New()
+	app.Use(NewMiddleware(cfg))
+	app.GET("/", func(c echo.Context) error {
+		return c.String(http.StatusOK, MustLocalize(c, "welcome"))
+	})
+
+	got, err := makeRequest(language.Chinese, "", app)
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(got.Body)
+	assert.Equal(t, "你好", string(body))
+
+	_, err = cfg.TranslateFile("zh")
+	assert.EqualError(t, err, "i18n.TranslateFile error: format props of language zh has no Marshal function")
+}
+
+// TestConfig_LanguageFormats tests loading legacy languages in another format.
+func TestConfig_LanguageFormats(t *testing.T) {
+	t.Parallel()
+	cfg := &Config{
+		LanguageFormats: map[string]string{"zh": "json"},
+		Loader: LoaderFunc(func(path string) ([]byte, error) {
+			return map[string][]byte{
+				"example/localize/en.yaml": []byte("welcome: hello\nbye: goodbye"),
+				"example/localize/zh.json": []byte(`{"welcome": "你好"}`),
+			}[path], nil
+		}),
+	}
+	app := echo.New()
+	app.Use(NewMiddleware(cfg))
+	app.GET("/", func(c echo.Context) error {
+		return c.String(http.StatusOK, MustLocalize(c, "welcome"))
+	})
+
+	got, err := makeRequest(language.Chinese, "", app)
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(got.Body)
+	assert.Equal(t, "你好", string(body))
+
+	translate, err := cfg.TranslateFile("zh")
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"bye": {"hash": "sha1-3c8ec4874488f6090a157b014ce3397ca8e06d4f", "other": "goodbye"}}`, string(translate))
+}
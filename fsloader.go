@@ -0,0 +1,23 @@
+package echoi18n
+
+import (
+	iofs "io/fs"
+	"path"
+)
+
+// FSLoader loads message files from a filesystem, such as os.DirFS,
+// fstest.MapFS or a zip archive. Paths are relative to the filesystem root.
+type FSLoader struct {
+	FS iofs.FS // Filesystem of the message files.
+}
+
+// LoadMessage retrieves a file from the filesystem.
+// Returns the file content or an error.
+func (l *FSLoader) LoadMessage(name string) ([]byte, error) {
+	return iofs.ReadFile(l.FS, path.Clean(name))
+}
+
+// ListMessages lists the files below a directory of the filesystem.
+func (l *FSLoader) ListMessages(dir string) ([]string, error) {
+	return listFS(l.FS, path.Clean(dir))
+}
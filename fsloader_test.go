@@ -0,0 +1,48 @@
+package echoi18n
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"testing"
+	"testing/fstest"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// TestFSLoader tests loading message files from io/fs filesystems.
+func TestFSLoader(t *testing.T) {
+	t.Parallel()
+	mapFS := fstest.MapFS{
+		"localize/en.yaml": {Data: []byte("welcome: hello")},
+		"localize/zh.yaml": {Data: []byte("welcome: 你好")},
+	}
+
+	tests := []struct {
+		name     string
+		loader   Loader
+		rootPath string
+	}{
+		{"map", &FSLoader{FS: mapFS}, "./localize"},
+		{"dir", &FSLoader{FS: os.DirFS("example")}, "localize"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := echo.New()
+			app.Use(NewMiddleware(&Config{Loader: tt.loader, RootPath: tt.rootPath}))
+			app.GET("/", func(c echo.Context) error {
+				return c.String(http.StatusOK, MustLocalize(c, "welcome"))
+			})
+
+			got, err := makeRequest(language.Chinese, "", app)
+			assert.NoError(t, err)
+			body, _ := io.ReadAll(got.Body)
+			assert.Equal(t, "你好", string(body))
+		})
+	}
+
+	_, err := (&FSLoader{FS: mapFS}).LoadMessage("localize/fr.yaml")
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,202 @@
+package echoi18n
+
+import (
+	"container/list"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/labstack/echo/v4"
+)
+
+// defaultGlossaryCache is the number of glossaries kept in memory when
+// GlossaryCache is 0.
+const defaultGlossaryCache = 1000
+
+// GlossaryLoader loads the term substitutions of the glossary of a user or
+// organization for a language, e.g. {"Deal": "Opportunity"}.
+type GlossaryLoader func(key, lang string) (map[string]string, error)
+
+// glossary substitutes the terms of a glossary as whole words.
+type glossary struct {
+	key          string   // Cache key of the glossary.
+	terms        []string // Terms, longest first.
+	replacements map[string]string
+}
+
+// glossaryCache keeps the loaded glossaries, evicting the least recently used
+// ones beyond max glossaries.
+type glossaryCache struct {
+	mu         sync.Mutex
+	max        int                      // Maximum number of glossaries.
+	order      *list.List               // Glossaries, most recently used first.
+	glossaries map[string]*list.Element // Elements of order keyed by cache key.
+}
+
+// newGlossaryCache creates a cache of at most max glossaries, 1000 when 0.
+func newGlossaryCache(max int) *glossaryCache {
+	if max <= 0 {
+		max = defaultGlossaryCache
+	}
+	return &glossaryCache{max: max, order: list.New(), glossaries: map[string]*list.Element{}}
+}
+
+// get returns the cached glossary of a cache key and marks it as recently used.
+func (g *glossaryCache) get(cacheKey string) (*glossary, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	elem, ok := g.glossaries[cacheKey]
+	if !ok {
+		return nil, false
+	}
+	g.order.MoveToFront(elem)
+	return elem.Value.(*glossary), true
+}
+
+// add caches a glossary, evicting the least recently used ones over the limit.
+func (g *glossaryCache) add(gl *glossary) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if elem, ok := g.glossaries[gl.key]; ok {
+		elem.Value = gl
+		g.order.MoveToFront(elem)
+		return
+	}
+	g.glossaries[gl.key] = g.order.PushFront(gl)
+	for g.order.Len() > g.max {
+		oldest := g.order.Back()
+		g.order.Remove(oldest)
+		delete(g.glossaries, oldest.Value.(*glossary).key)
+	}
+}
+
+// removePrefix drops the glossaries whose cache key starts with prefix.
+func (g *glossaryCache) removePrefix(prefix string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for cacheKey, elem := range g.glossaries {
+		if strings.HasPrefix(cacheKey, prefix) {
+			g.order.Remove(elem)
+			delete(g.glossaries, cacheKey)
+		}
+	}
+}
+
+// glossaryCacheKey returns the cache key of the glossary of a language.
+func glossaryCacheKey(key, lang string) string {
+	return key + "\x00" + lang
+}
+
+// newGlossary creates the glossary of term substitutions. Longer terms take
+// precedence over their prefixes.
+func newGlossary(cacheKey string, replacements map[string]string) *glossary {
+	gl := &glossary{key: cacheKey, terms: make([]string, 0, len(replacements)), replacements: make(map[string]string, len(replacements))}
+	for term, replacement := range replacements {
+		if term != "" {
+			gl.terms = append(gl.terms, term)
+			gl.replacements[term] = replacement
+		}
+	}
+	terms := gl.terms
+	sort.Slice(terms, func(i, j int) bool {
+		if len(terms[i]) != len(terms[j]) {
+			return len(terms[i]) > len(terms[j])
+		}
+		return terms[i] < terms[j]
+	})
+	return gl
+}
+
+// isWordRune reports whether r continues a word, so a term next to it is part
+// of a longer word. Scripts written without spaces have no word boundaries.
+func isWordRune(r rune) bool {
+	if unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Thai, unicode.Lao, unicode.Khmer, unicode.Myanmar) {
+		return false
+	}
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.Mn, r)
+}
+
+// replace substitutes the terms of the glossary found as whole words in a
+// message: "Deal" is replaced in "Deal" and "a Deal." but not in "Ideal" or
+// "Dealer".
+func (g *glossary) replace(message string) string {
+	if len(g.terms) == 0 {
+		return message
+	}
+	var b strings.Builder
+	prev := rune(-1)
+	for i := 0; i < len(message); {
+		if term, ok := g.match(message[i:], prev); ok {
+			b.WriteString(g.replacements[term])
+			i += len(term)
+			prev, _ = utf8.DecodeLastRuneInString(term)
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(message[i:])
+		b.WriteString(message[i : i+size])
+		i += size
+		prev = r
+	}
+	return b.String()
+}
+
+// match returns the longest term starting s as a whole word, prev being the
+// rune before s, -1 at the start of the message.
+func (g *glossary) match(s string, prev rune) (string, bool) {
+	for _, term := range g.terms {
+		if !strings.HasPrefix(s, term) {
+			continue
+		}
+		first, _ := utf8.DecodeRuneInString(term)
+		if prev >= 0 && isWordRune(prev) && isWordRune(first) {
+			continue
+		}
+		last, _ := utf8.DecodeLastRuneInString(term)
+		if next, _ := utf8.DecodeRuneInString(s[len(term):]); len(s) > len(term) && isWordRune(next) && isWordRune(last) {
+			continue
+		}
+		return term, true
+	}
+	return "", false
+}
+
+// loadGlossary returns the cached glossary of a key and language, loading it
+// with GlossaryLoader on first use.
+func (c *Config) loadGlossary(key, lang string) (*glossary, error) {
+	cacheKey := glossaryCacheKey(key, lang)
+	if gl, ok := c.glossaries.get(cacheKey); ok {
+		return gl, nil
+	}
+	replacements, err := c.GlossaryLoader(key, lang)
+	if err != nil {
+		return nil, err
+	}
+	gl := newGlossary(cacheKey, replacements)
+	c.glossaries.add(gl)
+	return gl, nil
+}
+
+// applyGlossary substitutes the terms of the request glossary in a localized
+// message, e.g. "Opportunity" for "Deal" for an organization.
+func (c *Config) applyGlossary(ctx echo.Context, lang, message string) (string, error) {
+	if c.GlossaryKey == nil || c.GlossaryLoader == nil {
+		return message, nil
+	}
+	key := c.GlossaryKey(ctx)
+	if key == "" {
+		return message, nil
+	}
+	gl, err := c.loadGlossary(key, lang)
+	if err != nil {
+		return "", err
+	}
+	return gl.replace(message), nil
+}
+
+// InvalidateGlossary drops the cached glossary of a key, so it is loaded
+// again with GlossaryLoader on its next use.
+func (c *Config) InvalidateGlossary(key string) {
+	c.active().glossaries.removePrefix(glossaryCacheKey(key, ""))
+}
@@ -0,0 +1,112 @@
+package echoi18n
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// TestConfig_Glossary tests substituting per-organization terms with caching.
+func TestConfig_Glossary(t *testing.T) {
+	t.Parallel()
+	loads := 0
+	glossaries := map[string]map[string]string{
+		"acme": {"Deal": "Opportunity", "Deals": "Opportunities"},
+	}
+	cfg := &Config{
+		AcceptLanguages: []language.Tag{language.English},
+		Bundles:         []Bundle{{"en": {{ID: "deals", Other: "Deals"}, {ID: "dealer", Other: "Ideal Deal for the Dealer."}}}},
+		Loader:          LoaderFunc(func(path string) ([]byte, error) { return nil, nil }),
+		GlossaryKey: func(c echo.Context) string {
+			return c.Request().Header.Get("X-Org")
+		},
+		GlossaryLoader: func(key, lang string) (map[string]string, error) {
+			loads++
+			if key == "broken" {
+				return nil, errors.New("glossary unavailable")
+			}
+			return glossaries[key], nil
+		},
+	}
+	app := echo.New()
+	app.Use(NewMiddleware(cfg))
+	app.GET("/:id", func(c echo.Context) error {
+		message, err := Localize(c, c.Param("id"))
+		if err != nil {
+			return c.String(http.StatusInternalServerError, err.Error())
+		}
+		return c.String(http.StatusOK, message)
+	})
+
+	request := func(org, id string) string {
+		req, _ := http.NewRequest(http.MethodGet, "/"+id, nil)
+		req.Header.Set("X-Org", org)
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+		body, _ := io.ReadAll(rec.Body)
+		return string(body)
+	}
+
+	assert.Equal(t, "Opportunities", request("acme", "deals"))
+	assert.Equal(t, "Deals", request("", "deals"))
+	assert.Equal(t, "Deals", request("other", "deals"))
+	assert.Equal(t, "i18n.Localize error: glossary unavailable", request("broken", "deals"))
+	assert.Equal(t, 3, loads)
+
+	assert.Equal(t, "Ideal Opportunity for the Dealer.", request("acme", "dealer"))
+
+	glossaries["acme"] = map[string]string{"Deal": "Contract"}
+	assert.Equal(t, "Opportunities", request("acme", "deals"))
+	cfg.InvalidateGlossary("acme")
+	assert.Equal(t, "Deals", request("acme", "deals"))
+	assert.Equal(t, "Ideal Contract for the Dealer.", request("acme", "dealer"))
+	assert.Equal(t, 4, loads)
+}
+
+// Test_glossary_replace tests substituting glossary terms as whole words.
+func Test_glossary_replace(t *testing.T) {
+	t.Parallel()
+	gl := newGlossary("acme", map[string]string{"Deal": "Opportunity", "Deal room": "Workspace", "C++": "Go", "交易": "商机"})
+	tests := []struct {
+		name    string
+		message string
+		want    string
+	}{
+		{"word", "Deal", "Opportunity"},
+		{"punctuation", "New Deal: (Deal).", "New Opportunity: (Opportunity)."},
+		{"longest term", "Open the Deal room", "Open the Workspace"},
+		{"inside words", "Ideal Dealer Deals", "Ideal Dealer Deals"},
+		{"symbols", "C++ code", "Go code"},
+		{"no spaces", "新交易已创建", "新商机已创建"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, gl.replace(tt.message))
+		})
+	}
+}
+
+// Test_glossaryCache tests evicting the least recently used glossaries.
+func Test_glossaryCache(t *testing.T) {
+	t.Parallel()
+	cache := newGlossaryCache(2)
+	for _, key := range []string{"a", "b"} {
+		cache.add(newGlossary(glossaryCacheKey(key, "en"), nil))
+	}
+	_, ok := cache.get(glossaryCacheKey("a", "en"))
+	assert.True(t, ok)
+	cache.add(newGlossary(glossaryCacheKey("c", "en"), nil))
+	_, ok = cache.get(glossaryCacheKey("b", "en"))
+	assert.False(t, ok)
+	cache.removePrefix(glossaryCacheKey("a", ""))
+	_, ok = cache.get(glossaryCacheKey("a", "en"))
+	assert.False(t, ok)
+	_, ok = cache.get(glossaryCacheKey("c", "en"))
+	assert.True(t, ok)
+}
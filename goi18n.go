@@ -0,0 +1,107 @@
+package echoi18n
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"io"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// messageHash returns the goi18n hash of a source message, which translations
+// record to tell which version of the source they were translated from.
+func messageHash(m *i18n.Message) string {
+	h := sha1.New()
+	_, _ = io.WriteString(h, m.Description)
+	_, _ = io.WriteString(h, m.Other)
+	return fmt.Sprintf("sha1-%x", h.Sum(nil))
+}
+
+// fileMessage converts a message to its message file representation.
+func fileMessage(m CatalogMessage) interface{} {
+	if m.Description == "" && m.Hash == "" && m.Zero == "" && m.One == "" && m.Two == "" && m.Few == "" && m.Many == "" {
+		return m.Other
+	}
+	fields := map[string]string{}
+	for key, value := range map[string]string{
+		"description": m.Description,
+		"hash":        m.Hash,
+		"zero":        m.Zero,
+		"one":         m.One,
+		"two":         m.Two,
+		"few":         m.Few,
+		"many":        m.Many,
+		"other":       m.Other,
+	} {
+		if value != "" {
+			fields[key] = value
+		}
+	}
+	return fields
+}
+
+// marshalMessages marshals messages as a message file of lang.
+func (c *Config) marshalMessages(lang string, messages []CatalogMessage) ([]byte, error) {
+	file := make(map[string]interface{}, len(messages))
+	for _, m := range messages {
+		file[m.ID] = fileMessage(m)
+	}
+	marshal := c.marshalFunc(lang)
+	if marshal == nil {
+		return nil, fmt.Errorf("format %s of language %s has no Marshal function", c.languageFormat(lang), lang)
+	}
+	return marshal(file)
+}
+
+// TranslateFile returns a goi18n translate file for lang: the default language
+// messages that are missing or outdated in lang, with the source hash recorded.
+// Load active files with FilePrefix "active." to complete the goi18n workflow.
+func (c *Config) TranslateFile(lang string) ([]byte, error) {
+	c = c.active()
+	translations := indexMessages(c.messages[lang])
+
+	var messages []CatalogMessage
+	for _, source := range c.messages[c.DefaultLanguage.String()] {
+		if translation, ok := translations[source.ID]; ok && !isOutdated(source, translation) {
+			continue
+		}
+		m := newCatalogMessage(source)
+		m.Hash = messageHash(source)
+		messages = append(messages, m)
+	}
+
+	buf, err := c.marshalMessages(lang, messages)
+	if err != nil {
+		return nil, fmt.Errorf("i18n.TranslateFile error: %v", err)
+	}
+	return buf, nil
+}
+
+// MergeTranslateFile merges a completed goi18n translate file into the active
+// messages of lang and returns the content of the new active file.
+func (c *Config) MergeTranslateFile(lang string, buf []byte) ([]byte, error) {
+	c = c.active()
+	path := fmt.Sprintf("translate.%s.%s", lang, c.languageFormat(lang))
+	translateFile, err := i18n.ParseMessageFileBytes(buf, path, c.unmarshalFuncs())
+	if err != nil {
+		return nil, fmt.Errorf("i18n.MergeTranslateFile error: %v", err)
+	}
+
+	merged := map[string]CatalogMessage{}
+	for _, m := range c.messages[lang] {
+		merged[m.ID] = newCatalogMessage(m)
+	}
+	for _, m := range translateFile.Messages {
+		merged[m.ID] = newCatalogMessage(m)
+	}
+
+	messages := make([]CatalogMessage, 0, len(merged))
+	for _, m := range merged {
+		messages = append(messages, m)
+	}
+	buf, err = c.marshalMessages(lang, messages)
+	if err != nil {
+		return nil, fmt.Errorf("i18n.MergeTranslateFile error: %v", err)
+	}
+	return buf, nil
+}
@@ -0,0 +1,43 @@
+package echoi18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestConfig_TranslateFile tests the goi18n translate and merge workflow.
+func TestConfig_TranslateFile(t *testing.T) {
+	t.Parallel()
+	cfg := &Config{RootPath: "testdata/goi18n", FilePrefix: "active."}
+	NewMiddleware(cfg)
+
+	got, err := cfg.TranslateFile("zh")
+	assert.NoError(t, err)
+	assert.YAMLEq(t, `
+goodbye:
+  hash: sha1-78c9a53e2f28b543ea62c8266acfdf36d5c63e61
+  other: bye
+welcomeWithName:
+  hash: sha1-ed4737d0c23efdd9fbe40d8822f683ff496d6f6c
+  other: hello {{ .name }}
+`, string(got))
+
+	got, err = cfg.MergeTranslateFile("zh", []byte(`
+goodbye:
+  hash: sha1-78c9a53e2f28b543ea62c8266acfdf36d5c63e61
+  other: 再见
+`))
+	assert.NoError(t, err)
+	assert.YAMLEq(t, `
+welcome:
+  hash: sha1-aaf4c61ddcc5e8a2dabede0f3b482cd9aea9434d
+  other: 你好
+goodbye:
+  hash: sha1-78c9a53e2f28b543ea62c8266acfdf36d5c63e61
+  other: 再见
+`, string(got))
+
+	_, err = cfg.MergeTranslateFile("zh", []byte("- : -"))
+	assert.Error(t, err)
+}
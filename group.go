@@ -0,0 +1,63 @@
+package echoi18n
+
+import (
+	"github.com/labstack/echo/v4"
+	"golang.org/x/text/language"
+)
+
+// groupKey is the Echo Context key of the GroupConfig of the request route group.
+const groupKey = "echoi18n.group"
+
+// GroupConfig overrides the language negotiation of a route group.
+type GroupConfig struct {
+	DefaultLanguage language.Tag                      // Default language of the group, the Config one when undefined or not loaded.
+	LangHandler     func(echo.Context, string) string // Language handler of the group, the Config one when nil.
+}
+
+// NewGroupMiddleware creates a middleware overriding the default language and
+// detection of a route group, e.g. a Latin-America section defaulting to
+// Spanish. It must run after the i18n middleware, whose catalog it shares.
+func NewGroupMiddleware(group GroupConfig) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set(groupKey, &group)
+			languageChanged(c)
+			return next(c)
+		}
+	}
+}
+
+// requestGroup returns the GroupConfig of the request route group, if any.
+func requestGroup(c echo.Context) *GroupConfig {
+	if c == nil {
+		return nil
+	}
+	group, _ := c.Get(groupKey).(*GroupConfig)
+	return group
+}
+
+// defaultLanguage returns the default language and language handler of the
+// request, reporting whether the default comes from DomainLanguages. A group
+// default language without localizer is replaced by the Config one.
+func (c *Config) defaultLanguage(ctx echo.Context) (string, func(echo.Context, string) string, bool) {
+	defaultLang, domain := c.domainLanguage(ctx)
+	langHandler := c.LangHandler
+	if group := requestGroup(ctx); group != nil {
+		if lang := group.DefaultLanguage.String(); group.DefaultLanguage != language.Und && c.isLoaded(lang) {
+			defaultLang, domain = lang, false
+		}
+		if group.LangHandler != nil {
+			langHandler = group.LangHandler
+		}
+	}
+	return defaultLang, langHandler, domain
+}
+
+// isLoaded reports whether lang has a localizer.
+func (c *Config) isLoaded(lang string) bool {
+	if c.localizerMap == nil {
+		return false
+	}
+	_, ok := c.localizerMap.Load(lang)
+	return ok
+}
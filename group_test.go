@@ -0,0 +1,53 @@
+package echoi18n
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// TestNewGroupMiddleware tests overriding the negotiation of a route group.
+func TestNewGroupMiddleware(t *testing.T) {
+	t.Parallel()
+	app := echo.New()
+	app.Use(NewMiddleware(&Config{CacheKeyHeader: HeaderCacheKey}))
+	welcome := func(c echo.Context) error {
+		return c.String(http.StatusOK, MustLocalize(c, "welcome"))
+	}
+	app.GET("/", welcome)
+	cn := app.Group("/cn", NewGroupMiddleware(GroupConfig{DefaultLanguage: language.Chinese}))
+	cn.GET("", welcome)
+	fixed := app.Group("/fixed", NewGroupMiddleware(GroupConfig{
+		LangHandler: func(c echo.Context, defaultLang string) string { return defaultLang },
+	}))
+	fixed.GET("", welcome)
+	es := app.Group("/es", NewGroupMiddleware(GroupConfig{DefaultLanguage: language.Spanish}))
+	es.GET("", welcome)
+
+	tests := []struct {
+		name string
+		lang language.Tag
+		url  string
+		want string
+	}{
+		{"root default", language.Und, "", "hello"},
+		{"group default", language.Und, "cn", "你好"},
+		{"group unsupported", language.French, "cn", "你好"},
+		{"group detected", language.English, "cn", "hello"},
+		{"group handler", language.Chinese, "fixed", "hello"},
+		{"group default not loaded", language.Und, "es", "hello"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := makeRequest(tt.lang, tt.url, app)
+			assert.NoError(t, err)
+			body, _ := io.ReadAll(got.Body)
+			assert.Equal(t, tt.want, string(body))
+			assert.Equal(t, map[string]string{"hello": "en", "你好": "zh"}[tt.want], got.Header.Get(HeaderCacheKey))
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package echoi18n
+
+import (
+	"errors"
+	"html"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// errorMessageID is the default message of HTTP errors caused by Localize errors.
+const errorMessageID = "echoi18n.error"
+
+// LocalizeError is the error returned by Localize.
+type LocalizeError struct {
+	MessageID string // ID of the message that failed to localize.
+	Err       error  // Cause of the failure.
+}
+
+// Error returns the error message.
+func (e *LocalizeError) Error() string {
+	return "i18n.Localize error: " + e.Err.Error()
+}
+
+// Unwrap returns the cause of the failure.
+func (e *LocalizeError) Unwrap() error {
+	return e.Err
+}
+
+// localizedHTTPError converts a Localize error to an HTTP error with the
+// configured status and the localized generic error message as body, or the
+// status text if it is missing.
+func localizedHTTPError(c echo.Context, err *LocalizeError) *echo.HTTPError {
+	status, messageID := http.StatusInternalServerError, errorMessageID
+	if appCfg, cfgErr := getConfig(c); cfgErr == nil {
+		if appCfg.ErrorStatus != 0 {
+			status = appCfg.ErrorStatus
+		}
+		if appCfg.ErrorMessageID != "" {
+			messageID = appCfg.ErrorMessageID
+		}
+	}
+	message, localizeErr := Localize(c, messageID)
+	if localizeErr != nil {
+		message = http.StatusText(status)
+	}
+	return echo.NewHTTPError(status, message).SetInternal(err)
+}
+
+// HTTPErrorHandler wraps an Echo error handler so handlers can return
+// Localize errors and errors registered with RegisterError directly,
+// answered with a localized message in the negotiated representation, plain
+// text and HTML ones written directly:
+//
+//	e.HTTPErrorHandler = echoi18n.HTTPErrorHandler(e.DefaultHTTPErrorHandler)
+func HTTPErrorHandler(next echo.HTTPErrorHandler) echo.HTTPErrorHandler {
+	return func(err error, c echo.Context) {
+		var httpErr *echo.HTTPError
+		var localizeErr *LocalizeError
+		if errors.As(err, &localizeErr) {
+			httpErr = localizedHTTPError(c, localizeErr)
+		} else if message, ok := LookupError(err); ok {
+			httpErr = registeredHTTPError(c, err, message)
+		} else {
+			next(err, c)
+			return
+		}
+		if c.Response().Committed {
+			return
+		}
+		message, _ := httpErr.Message.(string)
+		switch Negotiate(c).MediaType {
+		case echo.MIMETextPlain:
+			err = c.String(httpErr.Code, message)
+		case echo.MIMETextHTML:
+			err = c.HTML(httpErr.Code, html.EscapeString(message))
+		default:
+			next(httpErr, c)
+			return
+		}
+		if err != nil {
+			c.Logger().Error(err)
+		}
+	}
+}
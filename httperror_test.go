@@ -0,0 +1,77 @@
+package echoi18n
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// newErrorServer creates an Echo server returning Localize errors from handlers.
+func newErrorServer(cfg *Config) *echo.Echo {
+	app := echo.New()
+	app.HTTPErrorHandler = HTTPErrorHandler(app.DefaultHTTPErrorHandler)
+	app.Use(NewMiddleware(cfg))
+	app.GET("/", func(c echo.Context) error {
+		message, err := Localize(c, "missing")
+		if err != nil {
+			return fmt.Errorf("render: %w", err)
+		}
+		return c.String(http.StatusOK, message)
+	})
+	app.GET("/other", func(c echo.Context) error {
+		return errors.New("boom")
+	})
+	return app
+}
+
+// TestHTTPErrorHandler tests answering Localize errors with a localized error.
+func TestHTTPErrorHandler(t *testing.T) {
+	t.Parallel()
+	localized := newErrorServer(&Config{
+		Bundles: []Bundle{{
+			"en": {{ID: "echoi18n.error", Other: "Something went wrong"}},
+			"zh": {{ID: "echoi18n.error", Other: "出错了"}},
+		}},
+		ErrorStatus: http.StatusServiceUnavailable,
+	})
+	plain := newErrorServer(&Config{})
+
+	tests := []struct {
+		name       string
+		app        *echo.Echo
+		url        string
+		wantStatus int
+		wantBody   string
+	}{
+		{"localized", localized, "", http.StatusServiceUnavailable, `{"message":"出错了"}`},
+		{"status text", plain, "", http.StatusInternalServerError, `{"message":"Internal Server Error"}`},
+		{"other errors", localized, "other", http.StatusInternalServerError, `{"message":"Internal Server Error"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := makeRequest(language.Chinese, tt.url, tt.app)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, got.StatusCode)
+			body, _ := io.ReadAll(got.Body)
+			assert.JSONEq(t, tt.wantBody, string(body))
+		})
+	}
+}
+
+// TestLocalizeError tests the Localize error details.
+func TestLocalizeError(t *testing.T) {
+	c := echo.New().NewContext(nil, nil)
+	c.Set(localsKey, &resolution{catalog: configDefault(&Config{})})
+	_, err := Localize(c, 42)
+
+	var localizeErr *LocalizeError
+	assert.True(t, errors.As(err, &localizeErr))
+	assert.EqualError(t, err, "i18n.Localize error: Invalid params type")
+	assert.EqualError(t, errors.Unwrap(err), "Invalid params type")
+}
@@ -1,35 +1,165 @@
 package echoi18n
 
 import (
+	"errors"
 	"fmt"
-	"os"
-	"path"
+	"net/http"
 	"sync"
+	"sync/atomic"
+	"text/template"
+	"time"
 
 	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
 	"github.com/nicksnyder/go-i18n/v2/i18n"
 	"golang.org/x/text/language"
+	"golang.org/x/text/unicode/norm"
 	"gopkg.in/yaml.v3"
 )
 
-// localsKey is the key used to store the i18n Config in the Echo Context.
+// localsKey is the key used to store the resolution of the request, holding
+// its catalog snapshot, in the Echo Context.
 const localsKey = "echoi18n"
 
 // Config holds the configuration for the i18n middleware.
 type Config struct {
+	Skipper          middleware.Skipper                // Skips the middleware for a request, e.g. health checks or static assets.
 	DefaultLanguage  language.Tag                      // Default language to use if no language is determined.
 	AcceptLanguages  []language.Tag                    // Supported languages.
+	AutoLanguages    bool                              // Add the languages of the message files found in RootPath to AcceptLanguages at each load.
+	BetaLanguages    []language.Tag                    // Soft-launched AcceptLanguages, negotiated only by requests allowed by BetaAccess.
+	BetaAccess       func(echo.Context) bool           // Allows a request to negotiate BetaLanguages, e.g. with an internal header or cookie.
+	PreferredScripts map[string]string                 // Script matched for a requested bare language not in AcceptLanguages, by language, e.g. {"zh": "Hant", "sr": "Latn"}.
 	FormatBundleFile string                            // File format for message bundles.
-	Loader           Loader                            // Loader interface to load message files.
+	LanguageFormats  map[string]string                 // File format by language overriding FormatBundleFile, e.g. {"fr": "json"}.
+	Loader           Loader                            // Loader interface to load message files, from the filesystem by default; browser builds need an EmbedLoader or Bundles.
+	Layers           []Layer                           // Message file sources merged over Loader in increasing precedence, e.g. filesystem overrides then remote hotfixes.
 	RootPath         string                            // Root directory path for message files.
 	LangHandler      func(echo.Context, string) string // Language handler function.
+	Resolvers        []LanguageResolver                // Language detectors tried in order by the default LangHandler, path, query, cookie and header by default.
+	DetectTimeout    time.Duration                     // Deadline of the language detection of a request, set on its context, which resolvers must honor; the default language is served past it.
+	PathPrefix       func(lang string) string          // Path prefix of localized routes, "/<lang>" by default.
+	PathLanguage     bool                              // Detect the language from the PathPrefix of the request path first, e.g. "/zh/products".
+	IsBot            func(*http.Request) bool          // Reports whether the request comes from a bot or crawler.
+	CookieName       string                            // Cookie used to remember the selected language.
+	CookiePath       string                            // Path of the language cookie, "/" by default.
+	CookieMaxAge     time.Duration                     // Lifetime of the language cookie, one year by default.
+	PersistLanguage  func(echo.Context, string) error  // Persists a language explicitly selected by the user.
 	bundle           *i18n.Bundle                      // i18n message bundle.
 	localizerMap     *sync.Map                         // Map of localizers for each language.
-	mu               sync.Mutex                        // Mutex for thread safety.
+	fallbacks        map[string][]string               // Loaded languages of the FallbackChain of each language.
+	chains           *sync.Map                         // Localizers of the fallback chain of each language.
+	accept           *acceptMatcher                    // Matcher of the Accept-Language header against AcceptLanguages.
+	publicAccept     *acceptMatcher                    // Matcher of the AcceptLanguages without BetaLanguages, nil without them.
+	beta             map[string]bool                   // BetaLanguages by tag.
+	prefixes         []languagePrefix                  // Path prefixes of AcceptLanguages, longest first.
+	messages         Bundle                            // Loaded messages for each language.
+	index            map[string]messageIndex           // Loaded messages for each language keyed by ID.
+	metadata         map[string]map[string]Metadata    // Custom message fields for each language and ID.
+	mu               sync.RWMutex                      // Serializes the snapshot updates.
 	UnmarshalFunc    i18n.UnmarshalFunc                // Function to unmarshal message files.
+	MarshalFunc      func(interface{}) ([]byte, error) // Function to marshal generated message files.
+	FilePrefix       string                            // Prefix of message file names, e.g. "active." for goi18n.
+	LanguageDirs     bool                              // Also load the files of the language format below <RootPath>/<lang>/, e.g. "en/auth.yaml".
+	LanguageGlob     string                            // Pattern of the LanguageDirs files loaded, relative to the language directory, e.g. "emails/*.yaml".
+	ApprovedOnly     bool                              // Serve only translations in the approved state.
+	Bundles          []Bundle                          // Programmatic bundles, overridden by message files.
+	Funcs            template.FuncMap                  // Functions available in message templates.
+	SprigFuncs       bool                              // Enable a safe subset of Sprig functions in message templates.
+	AllowedFuncs     []string                          // Sandbox message templates to these functions when set, whatever the Funcs of a LocalizeConfig; methods of the data are not restricted.
+	LeftDelim        string                            // Custom left delimiter of message placeholders, e.g. "{".
+	RightDelim       string                            // Custom right delimiter of message placeholders, e.g. "}".
+	RawStrings       bool                              // Serve all messages verbatim without template execution.
+	PlaceholderCheck bool                              // Fail loading if translations use other placeholders than the default language.
+	BidiIsolate      bool                              // Isolate interpolated values in right-to-left languages.
+	Sanitizers       []Sanitizer                       // Applied in order to the template data map values or struct fields of every message before interpolation, e.g. TrimSpace.
+	IDNormalizers    []IDNormalizer                    // Applied in order to message IDs at load and lookup, e.g. FoldIDCase.
+	AuditData        AuditHandler                      // Reports template data keys unused or missing in the message of each Localize call.
+	NormalizeNFC     bool                              // Normalize localized messages to Unicode NFC.
+	Transliterate    func(lang, s string) string       // Custom transliteration applied by Slugify.
+	PhoneFormatter   PhoneFormatter                    // Phone number formatting backend of FormatPhone.
+	NameFormatter    NameFormatter                     // Personal name formatting backend of FormatName.
+	NumberSpeller    NumberSpeller                     // Number spell-out backend of SpellOut.
+	BinaryBytes      bool                              // Format sizes in binary units such as MiB in FormatBytes.
+	DebugHeaders     bool                              // Describe the negotiated language in X-I18n-* response headers.
+	TraceParam       string                            // Query parameter asking for the resolution trace of the request in X-I18n-Trace response headers, e.g. "i18n_trace"; disabled when empty.
+	TraceAccess      func(echo.Context) bool           // Allows a request to ask for its resolution trace, e.g. for support staff; all requests when nil.
+	CacheKeyHeader   string                            // Response header exposing CacheKey, e.g. HeaderCacheKey.
+	SurrogateKeys    bool                              // Tag responses with the locale and catalog version for CDN purges.
+	LoadWorkers      int                               // Message files loaded concurrently when above 1, which requires a Loader safe for concurrent use; sequentially by default.
+	LazyTemplates    bool                              // Compile message templates on first use instead of at load, skipping their validation.
+	Namespaces       []string                          // Catalog shards loaded on first use from <RootPath>/<namespace>/ files.
+	MaxShards        int                               // Namespace shards kept in memory besides CriticalShards, least recently used evicted first; unbounded when 0.
+	CriticalShards   []string                          // Namespaces loaded at startup, failing it if they do not load, and never evicted.
+	BackgroundLoad   bool                              // Load the other namespaces in the background after startup instead of on first use.
+	ProfileLabels    bool                              // Run handlers with a pprof label of the negotiated language.
+	RecoverHandler   RecoverHandler                    // Serves MustLocalize failures instead of panicking, e.g. RecoverMessageID in production.
+	Strict           TestingT                          // Fails the test on each Localize error, for handler tests in CI.
+	ErrorStatus      int                               // Status of Localize errors handled by HTTPErrorHandler, 500 by default.
+	ErrorMessageID   string                            // Message answering Localize errors in HTTPErrorHandler, "echoi18n.error" by default.
+	DomainLanguages  map[string]string                 // Regional default language by domain ("example.de") or TLD (".de").
+	MultipleChoices  bool                              // Answer 300 with the variants when several languages match equally in RootRedirectHandler.
+	GlossaryKey      func(echo.Context) string         // Glossary of the request user or organization, none when empty.
+	GlossaryLoader   GlossaryLoader                    // Loads the term substitutions of a glossary, cached until InvalidateGlossary.
+	GlossaryCache    int                               // Glossaries kept in memory, least recently used evicted first; 1000 when 0.
+	RenderCache      int                               // Rendered template messages kept in an LRU cache, disabled when 0.
+	Cache            Cache                             // Shared cache of rendered template messages, e.g. Redis, replacing RenderCache.
+	CacheTTL         time.Duration                     // Lifetime of cached rendered messages, unlimited when 0.
+	Clock            func() time.Time                  // Current time source of time-dependent features, time.Now by default.
+	After            TimerFunc                         // Timer source of the background reloads, time.NewTimer by default.
+	Rand             func() float64                    // Random source in [0, 1) of randomized features, e.g. ReloadJitter, math/rand by default.
+	Logger           echo.Logger                       // Logger of background errors, e.g. of reloads, the log package when nil.
+	MediaTypes       []string                          // Representations offered by Negotiate by preference, JSON, HTML and plain text by default.
+	WarmUp           bool                              // Load namespace shards, create the fallback chain localizers and compile lazy templates at startup instead of on first use.
+	VersionHeader    string                            // Response header exposing the catalog version, e.g. HeaderCatalogVersion.
+	NoLangHeaders    bool                              // Do not set the Content-Language response header and add Accept-Language to Vary.
+	Exclusive        bool                              // Panic at startup if middlewares of other Configs exist in the process.
+	Coordinator      Coordinator                       // Notifies peer instances of reloaded catalog versions and reloads on theirs.
+	ReloadInterval   time.Duration                     // Polls the message files and swaps in changed catalogs at this interval, disabled when 0.
+	ReloadJitter     time.Duration                     // Random delay of up to this duration added to each poll, so instances do not reload at once.
+	VersionHistory   int                               // Catalog versions kept in memory for Diff, 10 by default.
+	Variants         []string                          // Alternate catalogs loaded from <lang>-x-<variant> files, e.g. "inclusive".
+	VariantResolver  func(echo.Context) []string       // Variants preferred by the request, all Variants by default.
+	RegisterResolver func(echo.Context) string         // Formal or informal register of the request, neutral when empty.
+	FallbackChain    func(tag language.Tag) []string   // Languages tried in order, before DefaultLanguage, for messages missing in a language, e.g. ParentFallback.
+	ProtectedTerms   map[string]string                 // Brand and product terms translations keep verbatim, keyed by variable name.
+	InjectTerms      bool                              // Expose ProtectedTerms as template variables of every message.
+	TermAltered      func(lang, id, term string)       // Warns at load about translations that altered a protected term.
+	MissBudget       float64                           // Highest rate of messages missing in a language per window, e.g. 0.01; unchecked when 0.
+	ErrorBudget      float64                           // Highest rate of other Localize errors in a language per window; unchecked when 0.
+	BudgetWindow     int                               // Localize calls per language the budget rates are measured over, 1000 by default.
+	BudgetExceeded   BudgetHandler                     // Alert hook called when a language exceeds a budget over a window.
+	AcceptAnalytics  bool                              // Count the languages requested by Accept-Language headers, supported or not, reported by Stats.
+	ReadOnly         bool                              // Reject runtime catalog changes (SetState, Schedule, CancelSchedule, admin writes) with ErrReadOnly, e.g. in production.
+	parser           *messageParser                    // Message template parser.
+	raw              map[string]map[string]string      // Verbatim messages for each language and ID.
+	uncacheable      bool                              // Whether messages are marked non-cacheable.
+	variants         map[string]map[string]bool        // Loaded variant message IDs for each language.
+	version          string                            // Content hash of the loaded messages.
+	shards           *shardCache                       // Loaded namespace shards.
+	background       chan struct{}                     // Closed once the background namespaces are loaded.
+	glossaries       *glossaryCache                    // Cached glossaries keyed by glossary key and language.
+	placeholders     sync.Map                          // Template data keys used by each message, keyed by language.
+	renders          Cache                             // Cache of rendered template messages.
+	diagnostics      Diagnostics                       // Report of the last catalog load.
+	origin           *Config                           // Config publishing the snapshot, nil for the Config itself.
+	settings         *Config                           // Settings of the snapshots, with default values.
+	current          atomic.Pointer[Config]            // Current catalog snapshot.
+	budgets          *budgetMeter                      // Miss and error rates of the current budget windows.
+	demand           *demandCounter                    // Languages requested by the Accept-Language headers.
+	layers           []*layerLoader                    // Loaders of the Layers, caching their files for their RefreshInterval.
+	base             *layerLoader                      // Loader of the Loader files while the Layers are polled, nil otherwise.
+	detectTimeouts   atomic.Int64                      // Language detections past DetectTimeout.
+	history          catalogHistory                    // Messages of the last published catalog versions.
+	stop             chan struct{}                     // Closed by Close to stop watching the message files.
+	watched          chan struct{}                     // Closed once the message files are no longer watched.
+	scheduled        atomic.Pointer[scheduledCatalog]  // Catalog staged by Schedule.
+	registrations    int                               // Middlewares created for the Config, guarded by the registry.
+	states           map[string]map[string]string      // Workflow states set by SetState for each language and ID, kept across reloads.
 }
 
-// Loader is the interface for loading message files.
+// Loader is the interface for loading message files. LoadMessage is called
+// from a single goroutine at a time, unless LoadWorkers is above 1.
 type Loader interface {
 	LoadMessage(path string) ([]byte, error)
 }
@@ -42,34 +172,49 @@ func (f LoaderFunc) LoadMessage(path string) ([]byte, error) {
 	return f(path)
 }
 
-// loadMessage loads a single message file for a given language.
-func (c *Config) loadMessage(filepath string) {
-	buf, err := c.Loader.LoadMessage(filepath)
-	if err != nil {
-		panic(err)
-	}
-	if _, err := c.bundle.ParseMessageFileBytes(buf, filepath); err != nil {
-		panic(err)
-	}
+// unmarshalFuncs returns the unmarshal functions of the registered formats,
+// with UnmarshalFunc registered for FormatBundleFile.
+func (c *Config) unmarshalFuncs() map[string]i18n.UnmarshalFunc {
+	funcs := formatUnmarshalFuncs()
+	funcs[c.FormatBundleFile] = c.UnmarshalFunc
+	return funcs
 }
 
-// loadMessages loads all message files for the supported languages.
-func (c *Config) loadMessages() {
-	for _, lang := range c.AcceptLanguages {
-		bundleFilePath := fmt.Sprintf("%s.%s", lang.String(), c.FormatBundleFile)
-		filepath := path.Join(c.RootPath, bundleFilePath)
-		c.loadMessage(filepath)
+// addMessages records loaded messages, replacing earlier ones with the same ID.
+func (c *Config) addMessages(lang string, messages []*i18n.Message) {
+	c.messages[lang] = MergeBundles(Bundle{lang: c.messages[lang]}, Bundle{lang: messages})[lang]
+}
+
+// loadMessages loads the programmatic bundles and all message files for the
+// supported languages, reporting what was loaded and what failed.
+func (c *Config) loadMessages() Diagnostics {
+	c.messages = make(Bundle, len(c.AcceptLanguages))
+	c.metadata = make(map[string]map[string]Metadata, len(c.AcceptLanguages))
+	var diagnostics Diagnostics
+	for lang, messages := range MergeBundles(c.Bundles...) {
+		messages = c.normalizeMessages(messages)
+		if err := c.bundle.AddMessages(language.Make(lang), messages...); err != nil {
+			diagnostics = append(diagnostics, Diagnostic{Severity: SeverityError, Lang: lang, Message: err.Error(), Err: err})
+			continue
+		}
+		c.addMessages(lang, messages)
+	}
+	files := c.messageFiles()
+	c.readFiles(files)
+	for _, file := range files {
+		diagnostics = append(diagnostics, c.addFile(file))
 	}
+	return diagnostics
 }
 
 // initLocalizerMap initializes localizers for each supported language.
 func (c *Config) initLocalizerMap() {
 	localizerMap := &sync.Map{}
 
-	for _, lang := range c.AcceptLanguages {
-		s := lang.String()
+	parallel(len(c.AcceptLanguages), c.LoadWorkers, func(i int) {
+		s := c.AcceptLanguages[i].String()
 		localizerMap.Store(s, i18n.NewLocalizer(c.bundle, s))
-	}
+	})
 
 	lang := c.DefaultLanguage.String()
 	if _, ok := localizerMap.Load(lang); !ok {
@@ -80,100 +225,327 @@ func (c *Config) initLocalizerMap() {
 	c.mu.Unlock()
 }
 
-// Localize localizes a message using the provided context and parameters.
-func Localize(c echo.Context, params interface{}) (string, error) {
+// setHeaders sets the configured response headers of the request language
+// when the response is written, after any SetLanguage override.
+func (c *Config) setHeaders(ctx echo.Context) {
+	if !c.NoLangHeaders {
+		c.setLanguageHeaders(ctx)
+	}
+	if c.DebugHeaders {
+		c.setDebugHeaders(ctx)
+	}
+	if !Cacheable(ctx) {
+		ctx.Response().Header().Set(echo.HeaderCacheControl, "private, no-store")
+		return
+	}
+	if c.CacheKeyHeader != "" {
+		ctx.Response().Header().Set(c.CacheKeyHeader, CacheKey(ctx))
+	}
+	if c.SurrogateKeys {
+		c.setSurrogateKeys(ctx)
+	}
+}
+
+// getConfig returns the i18n Config stored in the Echo Context by the middleware.
+func getConfig(c echo.Context) (*Config, error) {
 	local := c.Get(localsKey)
 	if local == nil {
-		return "", fmt.Errorf("i18n.Localize error: %v", "Config is nil")
+		return nil, errors.New("Config is nil")
 	}
 
-	appCfg, ok := local.(*Config)
+	r, ok := local.(*resolution)
 	if !ok {
-		return "", fmt.Errorf("i18n.Localize error: %v", "Config is not *Config type")
+		return nil, fmt.Errorf("Config is not set by the i18n middleware: the %q context key holds a %T set by another middleware", localsKey, local)
 	}
+	return r.catalog.active(), nil
+}
 
-	lang := appCfg.LangHandler(c, appCfg.DefaultLanguage.String())
-	localizer, _ := appCfg.localizerMap.Load(lang)
+// language returns the supported language for the request, falling back to
+// the default language when the requested one has no localizer.
+func (c *Config) language(ctx echo.Context) string {
+	lang, _ := c.negotiate(ctx)
+	return lang
+}
 
-	if localizer == nil {
-		defaultLang := appCfg.DefaultLanguage.String()
-		localizer, _ = appCfg.localizerMap.Load(defaultLang)
+// Localize localizes a message using the provided context and parameters.
+func Localize(c echo.Context, params interface{}) (string, error) {
+	appCfg, err := getConfig(c)
+	if err != nil {
+		return "", &LocalizeError{MessageID: paramsMessageID(params), Err: err}
 	}
+	message, err := localize(c, appCfg, params)
+	appCfg.observe(c, err)
+	appCfg.reportStrict(c, err)
+	return message, err
+}
 
+// localize localizes a message with the request snapshot.
+func localize(c echo.Context, appCfg *Config, params interface{}) (string, error) {
 	var localizeConfig *i18n.LocalizeConfig
 	switch paramValue := params.(type) {
 	case string:
 		localizeConfig = &i18n.LocalizeConfig{MessageID: paramValue}
 	case *i18n.LocalizeConfig:
 		localizeConfig = paramValue
+	case MessageRef:
+		localizeConfig = paramValue.localizeConfig()
+	case *MessageRef:
+		localizeConfig = paramValue.localizeConfig()
 	default:
-		return "", fmt.Errorf("i18n.Localize error: %v", "Invalid params type")
+		return "", &LocalizeError{Err: errors.New("Invalid params type")}
+	}
+	if len(appCfg.IDNormalizers) > 0 {
+		normalized := *localizeConfig
+		normalized.MessageID = appCfg.normalizeID(normalized.MessageID)
+		localizeConfig = &normalized
 	}
 
-	message, err := localizer.(*i18n.Localizer).Localize(localizeConfig)
+	lang := appCfg.language(c)
+	if !appCfg.isServable(lang, localizeConfig.MessageID) {
+		lang = appCfg.DefaultLanguage.String()
+	}
+	localizeConfig = appCfg.withVariant(lang, appCfg.requestVariants(c), localizeConfig)
+	if appCfg.AuditData != nil {
+		appCfg.auditData(c, lang, localizeConfig)
+	}
+	cacheable := appCfg.isCacheable(lang, localizeConfig.MessageID)
+	if !cacheable {
+		c.Set(noCacheKey, true)
+	}
+	if message, ok := appCfg.rawMessage(lang, localizeConfig); ok {
+		return appCfg.postprocess(c, lang, params, message)
+	}
+	var cacheKey string
+	if cacheable && appCfg.renders != nil {
+		if key, ok := appCfg.renderKey(lang, localizeConfig); ok {
+			if message, ok := appCfg.renders.Get(key); ok {
+				return appCfg.postprocess(c, lang, params, message)
+			}
+			cacheKey = key
+		}
+	}
+	unsharded := localizeConfig
+	localizer, localizeConfig, err := appCfg.localizer(lang, localizeConfig)
+	if err != nil {
+		return "", &LocalizeError{MessageID: paramsMessageID(params), Err: err}
+	}
+	message, err := localizer.Localize(appCfg.prepare(lang, localizeConfig))
+	if err != nil && appCfg.fallbacks != nil {
+		message, err = appCfg.localizeFallback(c, lang, unsharded, err)
+	}
 	if err != nil {
-		return "", fmt.Errorf("i18n.Localize error: %v", err)
+		return "", &LocalizeError{MessageID: paramsMessageID(params), Err: err}
+	}
+	if cacheKey != "" {
+		appCfg.renders.Set(cacheKey, message, appCfg.CacheTTL)
 	}
-	return message, nil
+	return appCfg.postprocess(c, lang, params, message)
 }
 
-// MustLocalize is a helper function to localize a message, panicking on error.
+// prepare returns the localize config of a message in lang with its template
+// data sanitized, the protected terms injected and the values bidi-isolated
+// in right-to-left languages, and the template parser of the catalog.
+func (c *Config) prepare(lang string, lc *i18n.LocalizeConfig) *i18n.LocalizeConfig {
+	if len(c.Sanitizers) > 0 && lc.TemplateData != nil {
+		sanitized := *lc
+		sanitized.TemplateData = c.sanitize(lang, sanitized.TemplateData)
+		lc = &sanitized
+	}
+	if c.InjectTerms && len(c.ProtectedTerms) > 0 {
+		withTerms := *lc
+		withTerms.TemplateData = c.withTerms(withTerms.TemplateData)
+		lc = &withTerms
+	}
+	if c.BidiIsolate && lc.TemplateData != nil && IsRTL(language.Make(lang)) {
+		isolated := *lc
+		isolated.TemplateData = mapTemplateData(isolated.TemplateData, bidiIsolate)
+		lc = &isolated
+	}
+	return c.withParser(lc)
+}
+
+// postprocess applies the request glossary and the configured Unicode
+// normalization to a localized message.
+func (c *Config) postprocess(ctx echo.Context, lang string, params interface{}, message string) (string, error) {
+	message, err := c.applyGlossary(ctx, lang, message)
+	if err != nil {
+		return "", &LocalizeError{MessageID: paramsMessageID(params), Err: err}
+	}
+	return c.normalize(message), nil
+}
+
+// normalize applies the configured Unicode normalization to a message.
+func (c *Config) normalize(message string) string {
+	if c.NormalizeNFC {
+		return norm.NFC.String(message)
+	}
+	return message
+}
+
+// MustLocalize is a helper function to localize a message, panicking on error
+// unless a RecoverHandler is configured. In the strict test mode, the failed
+// test is left running with the message ID.
 func MustLocalize(c echo.Context, params interface{}) string {
 	message, err := Localize(c, params)
 	if err != nil {
+		if appCfg, cfgErr := getConfig(c); cfgErr == nil && appCfg.RecoverHandler != nil {
+			return appCfg.RecoverHandler(c, params, err)
+		} else if cfgErr == nil && appCfg.Strict != nil {
+			return paramsMessageID(params)
+		}
 		panic(err)
 	}
 	return message
 }
 
-// NewMiddleware creates a new i18n middleware handler with the provided configuration.
+// GetLocalizer returns the go-i18n localizer of the request language, to use
+// go-i18n features directly. Its messages bypass the processing of Localize,
+// such as namespaces, variants, fallback chains, caching and glossaries.
+func GetLocalizer(c echo.Context) (*i18n.Localizer, error) {
+	appCfg, err := getConfig(c)
+	if err != nil {
+		return nil, fmt.Errorf("i18n.GetLocalizer error: %v", err)
+	}
+	r := appCfg.resolve(c)
+	if r.localizer == nil {
+		return nil, fmt.Errorf("i18n.GetLocalizer error: no localizer for language %q", r.lang)
+	}
+	return r.localizer, nil
+}
+
+// NewMiddleware creates a new i18n middleware handler with the provided
+// configuration, ConfigDefault by default. The settings are copied: changing
+// the Config afterwards has no effect, and its methods report on the catalog
+// served by the middleware. Use Clone to reuse a Config for several servers.
+// Call Close on the Config once the middleware is no longer used, to release
+// it and its catalog. It panics with the error of NewMiddlewareWithError,
+// a *StartupError when the catalog fails to load.
 func NewMiddleware(config ...*Config) echo.MiddlewareFunc {
-	cfg := configDefault(config...)
-	bundle := i18n.NewBundle(cfg.DefaultLanguage)
-	bundle.RegisterUnmarshalFunc(cfg.FormatBundleFile, cfg.UnmarshalFunc)
-	cfg.bundle = bundle
+	middleware, err := newMiddleware(config...)
+	if err != nil {
+		panic(err)
+	}
+	return middleware
+}
 
-	cfg.loadMessages()
-	cfg.initLocalizerMap()
+// newMiddleware creates the middleware of NewMiddleware, returning the
+// catalog load failures as a *StartupError.
+func newMiddleware(config ...*Config) (echo.MiddlewareFunc, error) {
+	cfg := &Config{}
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+	registered, err := registry.register(cfg)
+	if err != nil {
+		return nil, err
+	}
+	cfg.settings = configDefault(config...)
+	cfg.parser = cfg.settings.newMessageParser()
+	cfg.budgets = newBudgetMeter()
+	cfg.demand = newDemandCounter()
+	cfg.base, cfg.layers = newLayerLoaders(cfg.settings)
+	snapshot := cfg.newSnapshot()
+	if err := snapshot.build(); err != nil {
+		registry.unregister(cfg)
+		return nil, &StartupError{Diagnostics: snapshot.diagnostics.Filter(SeverityError)}
+	}
+	snapshot.diagnostics = append(snapshot.diagnostics, registered...)
+	cfg.store(snapshot)
+	if err := cfg.subscribe(); err != nil {
+		registry.unregister(cfg)
+		return nil, err
+	}
+	cfg.stopWatching()
+	if interval := cfg.settings.pollInterval(); interval > 0 {
+		cfg.mu.Lock()
+		cfg.stop, cfg.watched = make(chan struct{}), make(chan struct{})
+		go cfg.watch(cfg.settings, interval, cfg.stop, cfg.watched)
+		cfg.mu.Unlock()
+	}
 
+	var nested sync.Once
+	skipper := cfg.settings.Skipper
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			c.Set(localsKey, cfg)
+			if skipper != nil && skipper(c) {
+				return next(c)
+			}
+			cfg.checkNested(c, &nested)
+			cfg.activateScheduled()
+			snapshot := cfg.active()
+			c.Set(localsKey, &resolution{catalog: snapshot})
+			if snapshot.AcceptAnalytics {
+				snapshot.countDemand(c.Request().Header.Get("Accept-Language"))
+			}
+			c.Set(VersionContextKey, snapshot.Version())
+			if snapshot.VersionHeader != "" {
+				c.Response().Header().Set(snapshot.VersionHeader, snapshot.Version())
+			}
+			if snapshot.CacheKeyHeader != "" {
+				CacheKey(c)
+			}
+			if !snapshot.NoLangHeaders || snapshot.DebugHeaders || snapshot.CacheKeyHeader != "" || snapshot.SurrogateKeys || snapshot.uncacheable {
+				c.Response().Before(func() { snapshot.setHeaders(c) })
+			}
+			if snapshot.tracing(c) {
+				c.Set(traceKey, &resolutionTrace{})
+				c.Response().Before(func() { snapshot.setTraceHeaders(c) })
+			}
+			if snapshot.ProfileLabels {
+				return snapshot.serveLabeled(c, next)
+			}
 			return next(c)
 		}
-	}
+	}, nil
 }
 
 var ConfigDefault = &Config{
 	DefaultLanguage:  language.English,
 	AcceptLanguages:  []language.Tag{language.Chinese, language.English},
 	FormatBundleFile: "yaml",
-	Loader:           LoaderFunc(os.ReadFile),
+	Loader:           defaultLoader,
 	RootPath:         "./example/localize",
 	LangHandler:      defaultLangHandler,
+	PathPrefix:       defaultPathPrefix,
+	IsBot:            defaultIsBot,
+	CookieName:       "lang",
+	CookiePath:       "/",
+	CookieMaxAge:     defaultCookieMaxAge,
+	PersistLanguage:  defaultPersistLanguage,
+	PhoneFormatter:   defaultFormatPhone,
+	NameFormatter:    defaultFormatName,
+	NumberSpeller:    defaultSpellOut,
+	TermAltered:      defaultTermAltered,
+	MediaTypes:       defaultMediaTypes,
 	UnmarshalFunc:    yaml.Unmarshal,
+	MarshalFunc:      yaml.Marshal,
 }
 
-// configDefault provides default values for the configuration
+// configDefault returns a copy of the configuration with default values,
+// leaving the configuration and ConfigDefault unchanged.
 func configDefault(config ...*Config) *Config {
 
 	if len(config) == 0 {
-		return ConfigDefault
+		return ConfigDefault.Clone()
 	}
 
-	cfg := config[0]
+	cfg := config[0].Clone()
 
 	if cfg.DefaultLanguage == language.Und {
 		cfg.DefaultLanguage = language.English
 	}
-	if cfg.AcceptLanguages == nil {
+	if cfg.AcceptLanguages == nil && !cfg.AutoLanguages {
 		cfg.AcceptLanguages = []language.Tag{language.Chinese, language.English}
 	}
 	if cfg.FormatBundleFile == "" {
 		cfg.FormatBundleFile = "yaml"
 	}
 	if cfg.Loader == nil {
-		cfg.Loader = LoaderFunc(os.ReadFile)
+		cfg.Loader = defaultLoader
+	}
+	cfg.Loader = cfg.withClock(cfg.Loader)
+	for i := range cfg.Layers {
+		cfg.Layers[i].Loader = cfg.withClock(cfg.Layers[i].Loader)
 	}
 	if cfg.RootPath == "" {
 		cfg.RootPath = "./example/localize"
@@ -181,27 +553,83 @@ func configDefault(config ...*Config) *Config {
 	if cfg.LangHandler == nil {
 		cfg.LangHandler = defaultLangHandler
 	}
+	if cfg.PathPrefix == nil {
+		cfg.PathPrefix = defaultPathPrefix
+	}
+	if cfg.IsBot == nil {
+		cfg.IsBot = defaultIsBot
+	}
+	if cfg.CookieName == "" {
+		cfg.CookieName = "lang"
+	}
+	if cfg.CookiePath == "" {
+		cfg.CookiePath = "/"
+	}
+	if cfg.CookieMaxAge == 0 {
+		cfg.CookieMaxAge = defaultCookieMaxAge
+	}
+	if cfg.PersistLanguage == nil {
+		cfg.PersistLanguage = defaultPersistLanguage
+	}
+	if cfg.PhoneFormatter == nil {
+		cfg.PhoneFormatter = defaultFormatPhone
+	}
+	if cfg.NameFormatter == nil {
+		cfg.NameFormatter = defaultFormatName
+	}
+	if cfg.NumberSpeller == nil {
+		cfg.NumberSpeller = defaultSpellOut
+	}
+	if cfg.TermAltered == nil {
+		cfg.TermAltered = defaultTermAltered
+	}
+	if len(cfg.MediaTypes) == 0 {
+		cfg.MediaTypes = defaultMediaTypes
+	}
 
+	format, ok := lookupFormat(cfg.FormatBundleFile)
+	if !ok {
+		format, _ = lookupFormat("yaml")
+	}
 	if cfg.UnmarshalFunc == nil {
-		cfg.UnmarshalFunc = yaml.Unmarshal
+		cfg.UnmarshalFunc = format.Unmarshal
+	}
+	if cfg.MarshalFunc == nil {
+		cfg.MarshalFunc = format.Marshal
 	}
 	return cfg
 }
 
-// defaultLangHandler returns the default language based on the request context.
+// defaultLangHandler returns the language of the first of the Resolvers
+// finding one, or the default language.
 func defaultLangHandler(c echo.Context, defaultLang string) string {
 	if c == nil || c.Request() == nil {
 		return defaultLang
 	}
-	var lang string
-	lang = c.QueryParam("lang")
-	if lang != "" {
-		return lang
+	resolvers := defaultResolvers
+	if appCfg, err := getConfig(c); err == nil && appCfg.Resolvers != nil {
+		resolvers = appCfg.Resolvers
 	}
-	lang = c.Request().Header.Get("Accept-Language")
-	if lang != "" {
+	trace := detectionTrace(c)
+	for _, resolver := range resolvers {
+		lang, ok := resolver.ResolveLanguage(c)
+		if trace != nil {
+			trace.resolved(resolver, lang, ok)
+		}
+		if !ok {
+			continue
+		}
+		if _, builtin := resolver.(*builtinResolver); !builtin {
+			c.Set(sourceKey, SourceHandler)
+		}
 		return lang
 	}
 
+	c.Set(sourceKey, SourceDefault)
 	return defaultLang
 }
+
+// defaultPathPrefix returns "/<lang>" as the path prefix of localized routes.
+func defaultPathPrefix(lang string) string {
+	return "/" + lang
+}
@@ -169,6 +169,78 @@ func TestLocalize(t *testing.T) {
 	})
 }
 
+// TestGetLocalizer tests localizing with the go-i18n localizer of the request.
+func TestGetLocalizer(t *testing.T) {
+	t.Parallel()
+	app := echo.New()
+	app.Use(NewMiddleware())
+	app.GET("/", func(c echo.Context) error {
+		localizer, err := GetLocalizer(c)
+		if err != nil {
+			return err
+		}
+		message, err := localizer.Localize(&i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{ID: "unread", One: "{{.PluralCount}} unread message", Other: "{{.PluralCount}} unread messages"},
+			PluralCount:    3,
+		})
+		if err != nil {
+			return err
+		}
+		welcome := localizer.MustLocalize(&i18n.LocalizeConfig{MessageID: "welcome"})
+		return c.String(http.StatusOK, welcome+" "+message)
+	})
+
+	got, err := makeRequest(language.English, "", app)
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(got.Body)
+	assert.Equal(t, "hello 3 unread messages", string(body))
+
+	c := app.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+	_, err = GetLocalizer(c)
+	assert.EqualError(t, err, "i18n.GetLocalizer error: Config is nil")
+}
+
+// TestConfig_Skipper tests bypassing the middleware for skipped requests,
+// with the Skipper of the Config the middleware was created with.
+func TestConfig_Skipper(t *testing.T) {
+	t.Parallel()
+	cfg := &Config{
+		Skipper: func(c echo.Context) bool {
+			return c.Path() == "/healthz"
+		},
+	}
+	app := echo.New()
+	app.Use(NewMiddleware(cfg))
+	cfg.Skipper = nil
+	handler := func(c echo.Context) error {
+		message, err := Localize(c, "welcome")
+		if err != nil {
+			return c.NoContent(http.StatusNoContent)
+		}
+		return c.String(http.StatusOK, message)
+	}
+	app.GET("/healthz", handler)
+	app.GET("/welcome", handler)
+
+	tests := []struct {
+		name     string
+		url      string
+		code     int
+		language string
+	}{
+		{"skipped", "healthz", http.StatusNoContent, ""},
+		{"negotiated", "welcome", http.StatusOK, "zh"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := makeRequest(language.Chinese, tt.url, app)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.code, got.StatusCode)
+			assert.Equal(t, tt.language, got.Header.Get(HeaderContentLanguage))
+		})
+	}
+}
+
 // Test_defaultLangHandler tests the default language handler.
 func Test_defaultLangHandler(t *testing.T) {
 	e := echo.New()
@@ -0,0 +1,57 @@
+package echoi18n
+
+import (
+	"strings"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// IDNormalizer rewrites a message ID. IDNormalizers are applied to the IDs of
+// the catalog at load and to the requested IDs at lookup, e.g. to adopt a
+// catalog exported from a system with other conventions.
+type IDNormalizer func(id string) string
+
+// FoldIDCase is an IDNormalizer lowercasing IDs.
+func FoldIDCase(id string) string {
+	return strings.ToLower(id)
+}
+
+// DotSeparators is an IDNormalizer replacing the slashes of IDs with dots,
+// the separator of nested and namespaced IDs.
+func DotSeparators(id string) string {
+	return strings.ReplaceAll(id, "/", ".")
+}
+
+// TrimIDPrefix returns an IDNormalizer removing a prefix from IDs, e.g. the
+// "app." prefix of a catalog shared with other applications.
+func TrimIDPrefix(prefix string) IDNormalizer {
+	return func(id string) string {
+		return strings.TrimPrefix(id, prefix)
+	}
+}
+
+// normalizeID applies the IDNormalizers in order to a message ID.
+func (c *Config) normalizeID(id string) string {
+	for _, normalize := range c.IDNormalizers {
+		id = normalize(id)
+	}
+	return id
+}
+
+// normalizeMessages returns the messages with normalized IDs, copying the
+// messages whose ID changes.
+func (c *Config) normalizeMessages(messages []*i18n.Message) []*i18n.Message {
+	if len(c.IDNormalizers) == 0 {
+		return messages
+	}
+	normalized := make([]*i18n.Message, len(messages))
+	for i, m := range messages {
+		normalized[i] = m
+		if id := c.normalizeID(m.ID); id != m.ID {
+			message := *m
+			message.ID = id
+			normalized[i] = &message
+		}
+	}
+	return normalized
+}
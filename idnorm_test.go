@@ -0,0 +1,62 @@
+package echoi18n
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// TestConfig_IDNormalizers tests normalizing message IDs at load and lookup.
+func TestConfig_IDNormalizers(t *testing.T) {
+	t.Parallel()
+	cfg := &Config{
+		AcceptLanguages: []language.Tag{language.English},
+		RootPath:        "localize",
+		Loader: LoaderFunc(func(path string) ([]byte, error) {
+			return []byte(`
+Home/Title:
+  other: Welcome
+  tone: warm
+app.Footer: Footer
+`), nil
+		}),
+		IDNormalizers: []IDNormalizer{TrimIDPrefix("app."), DotSeparators, FoldIDCase},
+	}
+	app := echo.New()
+	app.Use(NewMiddleware(cfg))
+	app.GET("/", func(c echo.Context) error {
+		message, err := Localize(c, c.QueryParam("id"))
+		if err != nil {
+			return c.NoContent(http.StatusNotFound)
+		}
+		return c.String(http.StatusOK, message)
+	})
+
+	tests := []struct {
+		id   string
+		want string
+	}{
+		{"home.title", "Welcome"},
+		{"Home/Title", "Welcome"},
+		{"footer", "Footer"},
+		{"app.FOOTER", "Footer"},
+		{"Home", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			got, err := makeRequest(language.English, "?id="+url.QueryEscape(tt.id), app)
+			assert.NoError(t, err)
+			body, _ := io.ReadAll(got.Body)
+			assert.Equal(t, tt.want, string(body))
+		})
+	}
+
+	info, err := cfg.Message("en", "HOME/TITLE")
+	assert.NoError(t, err)
+	assert.Equal(t, MessageInfo{ID: "home.title", Fields: Metadata{"tone": "warm"}}, info)
+}
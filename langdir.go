@@ -0,0 +1,77 @@
+package echoi18n
+
+import (
+	"errors"
+	"fmt"
+	iofs "io/fs"
+	"os"
+	"path"
+	"strings"
+)
+
+// FileLister is implemented by Loaders able to list the message files of a
+// directory, required by LanguageDirs. The default Loader, FSLoader and
+// EmbedLoader implement it.
+type FileLister interface {
+	// ListMessages returns the paths of the files below dir, recursively, in
+	// lexical order. It fails with an error matching os.ErrNotExist if dir
+	// does not exist.
+	ListMessages(dir string) ([]string, error)
+}
+
+// listFS lists the regular files below dir in a filesystem.
+func listFS(fsys iofs.FS, dir string) ([]string, error) {
+	var files []string
+	err := iofs.WalkDir(fsys, dir, func(name string, entry iofs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.Type().IsRegular() {
+			files = append(files, name)
+		}
+		return nil
+	})
+	return files, err
+}
+
+// languageDirFiles returns the message files of the <RootPath>/<lang>/
+// directory with the format of the language and matching LanguageGlob. A
+// missing directory has no files; a listing failure is returned as a file
+// failing to load.
+func (c *Config) languageDirFiles(lang string) []*messageFile {
+	dir := path.Join(c.RootPath, lang)
+	lister, ok := c.Loader.(FileLister)
+	if !ok {
+		return []*messageFile{{lang: lang, path: dir, part: true, err: fmt.Errorf("Loader %T cannot list message directories", c.Loader)}}
+	}
+	paths, err := lister.ListMessages(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return []*messageFile{{lang: lang, path: dir, part: true, err: err}}
+	}
+	if _, err := path.Match(c.LanguageGlob, ""); err != nil {
+		return []*messageFile{{lang: lang, path: dir, part: true, err: fmt.Errorf("LanguageGlob: %v", err)}}
+	}
+	ext := "." + c.languageFormat(lang)
+	var files []*messageFile
+	for _, p := range paths {
+		rel := strings.TrimPrefix(p, dir+"/")
+		if path.Ext(p) != ext || !c.matchLanguageGlob(rel) {
+			continue
+		}
+		files = append(files, &messageFile{lang: lang, path: p, part: true})
+	}
+	return files
+}
+
+// matchLanguageGlob reports whether a path relative to a language directory
+// matches LanguageGlob, matching every path when it is empty.
+func (c *Config) matchLanguageGlob(rel string) bool {
+	if c.LanguageGlob == "" {
+		return true
+	}
+	matched, _ := path.Match(c.LanguageGlob, rel)
+	return matched
+}
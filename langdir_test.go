@@ -0,0 +1,72 @@
+package echoi18n
+
+import (
+	"io"
+	"net/http"
+	"testing"
+	"testing/fstest"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// TestConfig_LanguageDirs tests loading the message files of per-language
+// directories.
+func TestConfig_LanguageDirs(t *testing.T) {
+	t.Parallel()
+	mapFS := fstest.MapFS{
+		"localize/en/auth.yaml":           {Data: []byte("login: Log in")},
+		"localize/en/emails/welcome.yaml": {Data: []byte("subject: Welcome aboard")},
+		"localize/zh.yaml":                {Data: []byte("welcome: 你好")},
+		"localize/zh/auth.yaml":           {Data: []byte("login: 登录")},
+	}
+
+	tests := []struct {
+		name   string
+		config *Config
+		lang   language.Tag
+		id     string
+		want   string
+	}{
+		{"os", &Config{RootPath: "testdata/langdirs", LanguageDirs: true}, language.English, "login", "Log in"},
+		{"os nested", &Config{RootPath: "testdata/langdirs", LanguageDirs: true}, language.English, "subject", "Welcome aboard"},
+		{"os merged", &Config{RootPath: "testdata/langdirs", LanguageDirs: true}, language.Chinese, "welcome", "你好"},
+		{"fs", &Config{Loader: &FSLoader{FS: mapFS}, RootPath: "./localize", LanguageDirs: true}, language.Chinese, "login", "登录"},
+		{"glob", &Config{RootPath: "testdata/langdirs", LanguageDirs: true, LanguageGlob: "emails/*"}, language.English, "login", ""},
+		{"glob matched", &Config{RootPath: "testdata/langdirs", LanguageDirs: true, LanguageGlob: "emails/*"}, language.English, "subject", "Welcome aboard"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := echo.New()
+			app.Use(NewMiddleware(tt.config))
+			app.GET("/", func(c echo.Context) error {
+				message, err := Localize(c, tt.id)
+				if err != nil {
+					return c.NoContent(http.StatusNotFound)
+				}
+				return c.String(http.StatusOK, message)
+			})
+
+			got, err := makeRequest(tt.lang, "", app)
+			assert.NoError(t, err)
+			body, _ := io.ReadAll(got.Body)
+			assert.Equal(t, tt.want, string(body))
+		})
+	}
+}
+
+// TestConfig_LanguageDirs_errors tests reporting language directories that
+// cannot be loaded.
+func TestConfig_LanguageDirs_errors(t *testing.T) {
+	t.Parallel()
+	_, err := NewMiddlewareWithError(&Config{
+		RootPath:     "testdata/langdirs",
+		LanguageDirs: true,
+		Loader:       LoaderFunc(func(path string) ([]byte, error) { return []byte("welcome: hi"), nil }),
+	})
+	assert.ErrorContains(t, err, "testdata/langdirs/en (en): Loader echoi18n.LoaderFunc cannot list message directories")
+
+	_, err = NewMiddlewareWithError(&Config{RootPath: "testdata/langdirs", LanguageDirs: true, LanguageGlob: "["})
+	assert.ErrorContains(t, err, "LanguageGlob: syntax error in pattern")
+}
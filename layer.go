@@ -0,0 +1,157 @@
+package echoi18n
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// Layer is a source of message files merged over the Loader and the layers
+// before it, e.g. filesystem overrides over an embedded baseline, then remote
+// hotfixes. A message of a layer replaces the message with the same language
+// and ID of the lower ones. Layers override the message files of the main
+// catalog and its variants: namespace shards and LanguageDirs files are read
+// from the Loader only. Files missing from a layer are skipped; other load
+// errors fail the catalog load like those of the Loader, so wrap remote
+// backends in a StaleLoader to keep serving through outages.
+type Layer struct {
+	Name            string        // Name of the layer in diagnostics, its position by default.
+	Loader          Loader        // Loader of the message files of the layer, read at the paths of the Loader.
+	RefreshInterval time.Duration // Reloads the files of the layer at this interval, independently of ReloadInterval; with the Loader files when 0.
+}
+
+// layerFile is a cached load result of a message file of a layer.
+type layerFile struct {
+	buf    []byte
+	err    error
+	loaded time.Time
+}
+
+// layerLoader loads the message files of a layer or of the Loader, serving
+// the copies loaded less than interval ago. It outlives the catalog
+// snapshots, so each layer refreshes on its own schedule. The files of the
+// Loader and of the layers without RefreshInterval refresh together, at
+// ReloadInterval and on every Reload.
+type layerLoader struct {
+	loader     Loader
+	name       string
+	interval   time.Duration // Refresh interval of the files, kept until expired when 0.
+	withLoader bool          // Whether the files refresh with those of the Loader.
+	cached     bool          // Whether the files are cached, only for RefreshInterval or while the Layers are polled.
+	now        func() time.Time
+	mu         sync.Mutex
+	files      map[string]layerFile
+}
+
+// newLayerLoaders creates the loaders of the Layers and, while the Layers are
+// polled, the loader of the Loader, so a poll only reads the files due.
+func newLayerLoaders(c *Config) (*layerLoader, []*layerLoader) {
+	polled := len(c.Layers) > 0 && c.pollInterval() > 0
+	newLoader := func(loader Loader, name string, interval time.Duration) *layerLoader {
+		l := &layerLoader{loader: loader, name: name, interval: interval, cached: interval > 0, now: c.now, files: map[string]layerFile{}}
+		if interval <= 0 {
+			l.interval, l.withLoader, l.cached = c.ReloadInterval, true, polled
+		}
+		return l
+	}
+	layers := make([]*layerLoader, len(c.Layers))
+	for i, layer := range c.Layers {
+		name := layer.Name
+		if name == "" {
+			name = fmt.Sprint(i + 1)
+		}
+		layers[i] = newLoader(layer.Loader, name, layer.RefreshInterval)
+	}
+	if !polled {
+		return nil, layers
+	}
+	return newLoader(c.Loader, "", 0), layers
+}
+
+// LoadMessage loads a message file of the layer, or returns its copy if it is
+// fresh. Load errors other than a missing file are not cached.
+func (l *layerLoader) LoadMessage(path string) ([]byte, error) {
+	if !l.cached {
+		return l.loader.LoadMessage(path)
+	}
+	now := l.now()
+	l.mu.Lock()
+	file, ok := l.files[path]
+	l.mu.Unlock()
+	if ok && (l.interval <= 0 || now.Sub(file.loaded) < l.interval) {
+		return file.buf, file.err
+	}
+	buf, err := l.loader.LoadMessage(path)
+	if err == nil || errors.Is(err, os.ErrNotExist) {
+		l.mu.Lock()
+		l.files[path] = layerFile{buf: buf, err: err, loaded: now}
+		l.mu.Unlock()
+	}
+	return buf, err
+}
+
+// expire drops the cached files refreshing with those of the Loader.
+func (l *layerLoader) expire() {
+	if !l.withLoader {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.files = map[string]layerFile{}
+}
+
+// expireLoader makes the next catalog load read again the files refreshing
+// with those of the Loader: explicit reloads read them all, while the polls
+// of ReloadInterval and RefreshInterval read only the files due.
+func (c *Config) expireLoader() {
+	root := c.root()
+	if root.base != nil {
+		root.base.expire()
+	}
+	for _, loader := range root.layers {
+		loader.expire()
+	}
+}
+
+// layerFiles returns the message files of the Layers in increasing
+// precedence: for each layer, the message file and variant catalogs of the
+// supported languages.
+func (c *Config) layerFiles() []*messageFile {
+	if len(c.Layers) == 0 {
+		return nil
+	}
+	loaders := c.root().layers
+	if loaders == nil {
+		_, loaders = newLayerLoaders(c)
+	}
+	variants := c.catalogVariants()
+	var files []*messageFile
+	for _, loader := range loaders {
+		for _, tag := range c.AcceptLanguages {
+			for _, file := range c.bundleFiles(tag.String(), variants) {
+				file.layer, file.loader = loader.name, loader
+				if loader.loader == nil {
+					file.err = fmt.Errorf("layer %s has no Loader", loader.name)
+				}
+				files = append(files, file)
+			}
+		}
+	}
+	return files
+}
+
+// pollInterval returns the interval the catalog is reloaded at: the shortest
+// of ReloadInterval and the RefreshInterval of the Layers, 0 if none is set.
+// A poll reads only the files due and rebuilds the catalog with the cached
+// copies of the others.
+func (c *Config) pollInterval() time.Duration {
+	interval := c.ReloadInterval
+	for _, layer := range c.Layers {
+		if layer.RefreshInterval > 0 && (interval <= 0 || layer.RefreshInterval < interval) {
+			interval = layer.RefreshInterval
+		}
+	}
+	return interval
+}
@@ -0,0 +1,161 @@
+package echoi18n
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// TestConfig_Layers tests merging the message files of the Layers over the
+// Loader, each layer refreshing on its own schedule.
+func TestConfig_Layers(t *testing.T) {
+	t.Parallel()
+	var mu sync.Mutex
+	now := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	base := map[string]string{
+		"localize/en.yaml": "welcome: Welcome\nbye: Bye\nhelp: Help",
+		"localize/zh.yaml": "welcome: 你好\nbye: 再见\nhelp: 帮助",
+	}
+	overrides := fstest.MapFS{
+		"localize/en.yaml": {Data: []byte("bye: See you")},
+	}
+	hotfixes := &reloadLoader{}
+	hotfixes.set("bye: Goodbye", nil)
+	cfg := &Config{
+		Loader: LoaderFunc(func(path string) ([]byte, error) {
+			return []byte(base[path]), nil
+		}),
+		RootPath: "localize",
+		Layers: []Layer{
+			{Name: "overrides", Loader: &FSLoader{FS: overrides}},
+			{Name: "hotfixes", Loader: hotfixes, RefreshInterval: time.Minute},
+		},
+		Clock: func() time.Time {
+			mu.Lock()
+			defer mu.Unlock()
+			return now
+		},
+	}
+	app := echo.New()
+	app.Use(NewMiddleware(cfg))
+	defer cfg.Close()
+	app.GET("/:id", func(c echo.Context) error {
+		return LocalizedString(c, http.StatusOK, c.Param("id"))
+	})
+	get := func(lang language.Tag, id string) string {
+		resp, err := makeRequest(lang, id, app)
+		assert.NoError(t, err)
+		body, _ := io.ReadAll(resp.Body)
+		return string(body)
+	}
+
+	assert.Equal(t, "Welcome", get(language.English, "welcome"))
+	assert.Equal(t, "Goodbye", get(language.English, "bye"))
+	assert.Equal(t, "再见", get(language.Chinese, "bye"))
+	assert.Contains(t, cfg.Diagnostics(), Diagnostic{Severity: SeverityInfo, File: "localize/en.yaml", Lang: "en", Message: "loaded 1 messages in layer overrides"})
+	assert.Contains(t, cfg.Diagnostics(), Diagnostic{Severity: SeverityInfo, File: "localize/zh.yaml", Lang: "zh", Message: "file not found in layer overrides, skipped"})
+
+	overrides["localize/en.yaml"] = &fstest.MapFile{Data: []byte("bye: See you\nhelp: Support")}
+	hotfixes.set("bye: Farewell", nil)
+	assert.NoError(t, cfg.Reload())
+	assert.Equal(t, "Support", get(language.English, "help"))
+	assert.Equal(t, "Goodbye", get(language.English, "bye"))
+
+	mu.Lock()
+	now = now.Add(time.Minute)
+	mu.Unlock()
+	assert.NoError(t, cfg.Reload())
+	assert.Equal(t, "Farewell", get(language.English, "bye"))
+}
+
+// TestConfig_Layers_poll tests reading only the files due on the polls of
+// the RefreshInterval of a layer, and all of them on Reload.
+func TestConfig_Layers_poll(t *testing.T) {
+	t.Parallel()
+	var mu sync.Mutex
+	now := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	reads := map[string]int{}
+	read := func(source string) {
+		mu.Lock()
+		defer mu.Unlock()
+		reads[source]++
+	}
+	counts := func() map[string]int {
+		mu.Lock()
+		defer mu.Unlock()
+		return map[string]int{"base": reads["base"], "overrides": reads["overrides"], "hotfixes": reads["hotfixes"]}
+	}
+	cfg := &Config{
+		AcceptLanguages: []language.Tag{language.English},
+		RootPath:        "localize",
+		Loader: LoaderFunc(func(path string) ([]byte, error) {
+			read("base")
+			return []byte("welcome: Welcome"), nil
+		}),
+		Layers: []Layer{
+			{Name: "overrides", Loader: LoaderFunc(func(path string) ([]byte, error) {
+				read("overrides")
+				return nil, os.ErrNotExist
+			})},
+			{Name: "hotfixes", RefreshInterval: time.Minute, Loader: LoaderFunc(func(path string) ([]byte, error) {
+				read("hotfixes")
+				return nil, os.ErrNotExist
+			})},
+		},
+		ReloadInterval: time.Hour,
+		Clock: func() time.Time {
+			mu.Lock()
+			defer mu.Unlock()
+			return now
+		},
+		After: func(time.Duration) <-chan time.Time { return nil },
+	}
+	NewMiddleware(cfg)
+	defer cfg.Close()
+	assert.Equal(t, map[string]int{"base": 1, "overrides": 1, "hotfixes": 1}, counts())
+
+	mu.Lock()
+	now = now.Add(time.Minute)
+	mu.Unlock()
+	_, err := cfg.reload(true)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"base": 1, "overrides": 1, "hotfixes": 2}, counts())
+
+	mu.Lock()
+	now = now.Add(time.Hour)
+	mu.Unlock()
+	_, err = cfg.reload(true)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"base": 2, "overrides": 2, "hotfixes": 3}, counts())
+
+	assert.NoError(t, cfg.Reload())
+	assert.Equal(t, map[string]int{"base": 3, "overrides": 3, "hotfixes": 3}, counts())
+}
+
+// TestConfig_pollInterval tests the interval the catalog is reloaded at.
+func TestConfig_pollInterval(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		cfg  *Config
+		want time.Duration
+	}{
+		{"disabled", &Config{Layers: []Layer{{}}}, 0},
+		{"reload interval", &Config{ReloadInterval: time.Hour}, time.Hour},
+		{"layer", &Config{Layers: []Layer{{RefreshInterval: time.Minute}}}, time.Minute},
+		{"shortest", &Config{ReloadInterval: time.Minute, Layers: []Layer{{RefreshInterval: time.Hour}}}, time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.cfg.pollInterval())
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package echoi18n
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"sync"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+)
+
+// messageFile is a message file of the catalog and its loading result.
+type messageFile struct {
+	lang    string            // Supported language of the file.
+	variant string            // Variant of the language, empty for its main catalog.
+	part    bool              // Whether the file is one of the LanguageDirs files of the language.
+	layer   string            // Name of the Layer of the file, empty for the Loader.
+	loader  Loader            // Loader of the file, the configured Loader when nil.
+	path    string            // Path passed to the Loader.
+	buf     []byte            // Loaded file content.
+	parsed  *i18n.MessageFile // Parsed messages.
+	err     error             // Loading or parsing error.
+}
+
+// parallel calls fn with every index below n on at most workers goroutines,
+// or in order on the calling goroutine if workers is below 2.
+func parallel(n, workers int, fn func(i int)) {
+	if workers < 2 {
+		for i := 0; i < n; i++ {
+			fn(i)
+		}
+		return
+	}
+	if workers > n {
+		workers = n
+	}
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
+
+// messageFiles returns the message files of the supported languages, each
+// preceded by its LanguageDirs files and followed by its <lang>-x-<variant>
+// catalogs, then the files of the Layers.
+func (c *Config) messageFiles() []*messageFile {
+	variants := c.catalogVariants()
+	files := make([]*messageFile, 0, len(c.AcceptLanguages)*(1+len(variants)))
+	for _, tag := range c.AcceptLanguages {
+		lang := tag.String()
+		if c.LanguageDirs {
+			files = append(files, c.languageDirFiles(lang)...)
+		}
+		files = append(files, c.bundleFiles(lang, variants)...)
+	}
+	if base := c.root().base; base != nil {
+		for _, file := range files {
+			file.loader = base
+		}
+	}
+	return append(files, c.layerFiles()...)
+}
+
+// bundleFiles returns the message file of a language followed by its
+// <lang>-x-<variant> catalogs.
+func (c *Config) bundleFiles(lang string, variants []string) []*messageFile {
+	format := c.languageFormat(lang)
+	bundleFilePath := fmt.Sprintf("%s%s.%s", c.FilePrefix, lang, format)
+	files := []*messageFile{{lang: lang, path: path.Join(c.RootPath, bundleFilePath)}}
+	for _, variant := range variants {
+		bundleFilePath := fmt.Sprintf("%s%s-x-%s.%s", c.FilePrefix, lang, variant, format)
+		files = append(files, &messageFile{lang: lang, variant: variant, path: path.Join(c.RootPath, bundleFilePath)})
+	}
+	return files
+}
+
+// readFiles loads and parses the message files, concurrently with
+// LoadWorkers workers, skipping files that already failed.
+func (c *Config) readFiles(files []*messageFile) {
+	unmarshalFuncs := c.unmarshalFuncs()
+	parallel(len(files), c.LoadWorkers, func(i int) {
+		file := files[i]
+		if file.err != nil {
+			return
+		}
+		loader := c.Loader
+		if file.loader != nil {
+			loader = file.loader
+		}
+		file.buf, file.err = loader.LoadMessage(file.path)
+		if file.err == nil {
+			file.parsed, file.err = i18n.ParseMessageFileBytes(file.buf, file.path, unmarshalFuncs)
+		}
+	})
+}
+
+// addFile adds the messages of a read file to the catalog and reports the
+// result. Missing variant catalogs and Layers files are skipped, as are
+// missing files of languages provided by Bundles or LanguageDirs files.
+func (c *Config) addFile(file *messageFile) Diagnostic {
+	diagnostic := Diagnostic{Severity: SeverityError, File: file.path, Lang: file.lang}
+	var from string
+	if file.layer != "" {
+		from = " in layer " + file.layer
+	}
+	if errors.Is(file.err, os.ErrNotExist) && (file.variant != "" || file.layer != "" || len(c.messages[file.lang]) > 0) {
+		diagnostic.Severity, diagnostic.Message = SeverityInfo, "file not found"+from+", skipped"
+		return diagnostic
+	}
+	if file.err != nil {
+		diagnostic.Message, diagnostic.Err = file.err.Error()+from, file.err
+		return diagnostic
+	}
+
+	tag, messages := file.parsed.Tag, c.normalizeMessages(file.parsed.Messages)
+	if file.variant != "" {
+		tag, messages = language.Make(file.lang), variantMessages(messages, file.variant)
+	} else if file.part {
+		tag = language.Make(file.lang)
+	}
+	if err := c.bundle.AddMessages(tag, messages...); err != nil {
+		diagnostic.Message, diagnostic.Err = err.Error(), err
+		return diagnostic
+	}
+	lang := tag.String()
+	c.addMessages(lang, messages)
+	if file.variant == "" {
+		if err := c.loadMetadata(lang, file.parsed.Format, file.buf); err != nil {
+			diagnostic.Message, diagnostic.Err = err.Error(), err
+			return diagnostic
+		}
+	}
+	diagnostic.Severity, diagnostic.Message = SeverityInfo, fmt.Sprintf("loaded %d messages%s", len(messages), from)
+	return diagnostic
+}
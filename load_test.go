@@ -0,0 +1,81 @@
+package echoi18n
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// Test_parallel tests visiting every index with a bounded number of workers.
+func Test_parallel(t *testing.T) {
+	var mu sync.Mutex
+	visited := map[int]bool{}
+	var running, peak int32
+	parallel(20, 3, func(i int) {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		mu.Lock()
+		visited[i] = true
+		if n > peak {
+			peak = n
+		}
+		mu.Unlock()
+		time.Sleep(time.Millisecond)
+	})
+
+	assert.Len(t, visited, 20)
+	assert.LessOrEqual(t, peak, int32(3))
+	parallel(0, 0, func(i int) { t.Fail() })
+}
+
+// TestConfig_LoadWorkers tests that concurrent loading keeps the file order.
+func TestConfig_LoadWorkers(t *testing.T) {
+	t.Parallel()
+	langs := []language.Tag{language.English}
+	for _, lang := range []string{"de", "fr", "es", "it", "ja", "ko", "pt", "ru", "zh"} {
+		langs = append(langs, language.Make(lang))
+	}
+	loader := LoaderFunc(func(path string) ([]byte, error) {
+		time.Sleep(time.Millisecond)
+		return []byte(fmt.Sprintf("welcome: %s", path)), nil
+	})
+
+	sequential := &Config{AcceptLanguages: langs, Loader: loader, Variants: []string{"inclusive"}, LoadWorkers: 1}
+	concurrent := &Config{AcceptLanguages: langs, Loader: loader, Variants: []string{"inclusive"}, LoadWorkers: 8}
+	NewMiddleware(sequential)
+	NewMiddleware(concurrent)
+
+	assert.Equal(t, sequential.active().messages, concurrent.active().messages)
+	assert.Equal(t, sequential.Version(), concurrent.Version())
+	assert.Len(t, concurrent.active().messages["ja"], 2)
+}
+
+// TestConfig_LoadWorkers_default tests calling the Loader from one goroutine
+// at a time without LoadWorkers.
+func TestConfig_LoadWorkers_default(t *testing.T) {
+	t.Parallel()
+	var mu sync.Mutex
+	var running, peak int32
+	loader := LoaderFunc(func(path string) ([]byte, error) {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		mu.Lock()
+		if n > peak {
+			peak = n
+		}
+		mu.Unlock()
+		time.Sleep(time.Millisecond)
+		return []byte("welcome: hello"), nil
+	})
+	NewMiddleware(&Config{
+		AcceptLanguages: []language.Tag{language.English, language.German, language.French, language.Chinese},
+		Loader:          loader,
+		Variants:        []string{"inclusive"},
+	})
+	assert.Equal(t, int32(1), peak)
+}
@@ -0,0 +1,6 @@
+//go:build !js
+
+package echoi18n
+
+// defaultLoader reads message files from the operating system filesystem.
+var defaultLoader Loader = osLoader{}
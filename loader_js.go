@@ -0,0 +1,23 @@
+//go:build js
+
+package echoi18n
+
+import "fmt"
+
+// jsLoader reads message files from the filesystem of the host, such as
+// Node.js, pointing browser builds, which have none, to embedded catalogs.
+type jsLoader struct {
+	osLoader
+}
+
+// defaultLoader reads message files from the filesystem of the host.
+var defaultLoader Loader = jsLoader{}
+
+// LoadMessage reads a file.
+func (l jsLoader) LoadMessage(path string) ([]byte, error) {
+	buf, err := l.osLoader.LoadMessage(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w; without a filesystem, e.g. in browsers, use an EmbedLoader or Bundles", err)
+	}
+	return buf, nil
+}
@@ -0,0 +1,30 @@
+package echoi18n
+
+import (
+	iofs "io/fs"
+	"os"
+	"path/filepath"
+)
+
+// osLoader reads message files from the operating system filesystem.
+type osLoader struct{}
+
+// LoadMessage reads a file.
+func (osLoader) LoadMessage(path string) ([]byte, error) {
+	return os.ReadFile(path)
+}
+
+// ListMessages lists the files below a directory.
+func (osLoader) ListMessages(dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(filepath.FromSlash(dir), func(name string, entry iofs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.Type().IsRegular() {
+			files = append(files, filepath.ToSlash(name))
+		}
+		return nil
+	})
+	return files, err
+}
@@ -0,0 +1,58 @@
+package echoi18n
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Access log fields of LogFields.
+const (
+	LogFieldLanguage = "lang"
+	LogFieldVersion  = "catalog_version"
+)
+
+// LogFields returns the negotiated language and the catalog version of the
+// request, to segment access logs by locale, e.g. from the LogValuesFunc of
+// Echo's RequestLogger middleware. Returns nil without the middleware.
+func LogFields(c echo.Context) map[string]string {
+	appCfg, err := getConfig(c)
+	if err != nil {
+		return nil
+	}
+	return map[string]string{
+		LogFieldLanguage: appCfg.language(c),
+		LogFieldVersion:  CatalogVersion(c),
+	}
+}
+
+// LoggerCustomTag writes LogFields as JSON object members for the ${custom}
+// tag of Echo's Logger middleware, registered before the i18n middleware:
+//
+//	e.Use(middleware.LoggerWithConfig(middleware.LoggerConfig{
+//		Format:        `{"uri":"${uri}","status":${status},${custom}}` + "\n",
+//		CustomTagFunc: echoi18n.LoggerCustomTag,
+//	}))
+func LoggerCustomTag(c echo.Context, buf *bytes.Buffer) (int, error) {
+	fields := LogFields(c)
+	if fields == nil {
+		fields = map[string]string{LogFieldLanguage: "", LogFieldVersion: ""}
+	}
+	encoded, err := json.Marshal(fields)
+	if err != nil {
+		return 0, err
+	}
+	return buf.Write(encoded[1 : len(encoded)-1])
+}
+
+// logf logs a background error, e.g. of a reload, with the Logger or the log
+// package. A nil Config logs with the log package.
+func (c *Config) logf(format string, args ...interface{}) {
+	if c != nil && c.Logger != nil {
+		c.Logger.Errorf(format, args...)
+		return
+	}
+	log.Printf(format, args...)
+}
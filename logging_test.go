@@ -0,0 +1,52 @@
+package echoi18n
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// TestLoggerCustomTag tests enriching access logs with the language and catalog version.
+func TestLoggerCustomTag(t *testing.T) {
+	t.Parallel()
+	var logs bytes.Buffer
+	cfg := &Config{}
+	app := echo.New()
+	app.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			err := next(c)
+			fmt.Fprintf(&logs, `{"status":%d,`, c.Response().Status)
+			LoggerCustomTag(c, &logs)
+			logs.WriteString("}\n")
+			return err
+		}
+	})
+	app.Use(NewMiddleware(cfg))
+	app.GET("/", func(c echo.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	_, err := makeRequest(language.Chinese, "", app)
+	assert.NoError(t, err)
+	var entry map[string]interface{}
+	assert.NoError(t, json.Unmarshal(logs.Bytes(), &entry))
+	assert.Equal(t, map[string]interface{}{
+		"status":          float64(http.StatusNoContent),
+		"lang":            "zh",
+		"catalog_version": cfg.Version(),
+	}, entry)
+
+	c := app.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+	assert.Nil(t, LogFields(c))
+	var buf bytes.Buffer
+	_, err = LoggerCustomTag(c, &buf)
+	assert.NoError(t, err)
+	assert.Equal(t, `"catalog_version":"","lang":""`, buf.String())
+}
@@ -0,0 +1,32 @@
+package echoi18n
+
+import (
+	"encoding/json"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// MessageRef references a message with its template data and plural count.
+// Services can build it without depending on go-i18n and handlers pass it to
+// Localize as is, by value or pointer.
+type MessageRef struct {
+	ID           string      `json:"id"`              // ID of the message.
+	TemplateData interface{} `json:"data,omitempty"`  // Data of the message template.
+	PluralCount  interface{} `json:"count,omitempty"` // Count selecting the plural form.
+	localized    *string     // Localized message set by LocalizedJSON.
+}
+
+// MarshalJSON encodes the localized message of a reference localized by
+// LocalizedJSON, or the reference itself.
+func (r MessageRef) MarshalJSON() ([]byte, error) {
+	if r.localized != nil {
+		return json.Marshal(*r.localized)
+	}
+	type plain MessageRef
+	return json.Marshal(plain(r))
+}
+
+// localizeConfig returns the go-i18n localize config of the reference.
+func (r MessageRef) localizeConfig() *i18n.LocalizeConfig {
+	return &i18n.LocalizeConfig{MessageID: r.ID, TemplateData: r.TemplateData, PluralCount: r.PluralCount}
+}
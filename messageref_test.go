@@ -0,0 +1,57 @@
+package echoi18n
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// TestLocalize_MessageRef tests localizing message references.
+func TestLocalize_MessageRef(t *testing.T) {
+	t.Parallel()
+	app := echo.New()
+	app.Use(NewMiddleware(&Config{
+		Bundles: []Bundle{{
+			"en": {{ID: "items", One: "{{ .Count }} item", Other: "{{ .Count }} items"}},
+		}},
+	}))
+	app.GET("/", func(c echo.Context) error {
+		return c.String(http.StatusOK, MustLocalize(c, MessageRef{ID: "welcomeWithName", TemplateData: map[string]string{"name": "Ann"}}))
+	})
+	app.GET("/items", func(c echo.Context) error {
+		return c.String(http.StatusOK, MustLocalize(c, &MessageRef{ID: "items", TemplateData: map[string]int{"Count": 2}, PluralCount: 2}))
+	})
+
+	tests := []struct {
+		name string
+		lang language.Tag
+		url  string
+		want string
+	}{
+		{"value", language.Chinese, "", "你好 Ann"},
+		{"pointer with plural count", language.English, "items", "2 items"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := makeRequest(tt.lang, tt.url, app)
+			assert.NoError(t, err)
+			body, _ := io.ReadAll(got.Body)
+			assert.Equal(t, tt.want, string(body))
+		})
+	}
+
+	assert.Equal(t, "items", paramsMessageID(MessageRef{ID: "items"}))
+}
+
+// TestMessageRef_MarshalJSON tests encoding references outside LocalizedJSON.
+func TestMessageRef_MarshalJSON(t *testing.T) {
+	t.Parallel()
+	got, err := json.Marshal(MessageRef{ID: "unreadMessages", PluralCount: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"id":"unreadMessages","count":2}`, string(got))
+}
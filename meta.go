@@ -0,0 +1,57 @@
+package echoi18n
+
+import (
+	"fmt"
+	"html"
+	"html/template"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"golang.org/x/text/language"
+)
+
+// OpenGraphLocale formats a language tag as an Open Graph locale
+// (language_TERRITORY), inferring the most likely territory, e.g. zh → zh_CN.
+func OpenGraphLocale(tag language.Tag) string {
+	base, _ := tag.Base()
+	region, conf := tag.Region()
+	if conf == language.No {
+		return base.String()
+	}
+	return base.String() + "_" + region.String()
+}
+
+// OpenGraphLocales returns the og:locale value of the negotiated language and
+// the og:locale:alternate values of the other supported languages.
+func OpenGraphLocales(c echo.Context) (string, []string, error) {
+	appCfg, err := getConfig(c)
+	if err != nil {
+		return "", nil, fmt.Errorf("i18n.OpenGraphLocales error: %v", err)
+	}
+
+	lang := appCfg.language(c)
+	locale := OpenGraphLocale(language.Make(lang))
+	alternates := make([]string, 0, len(appCfg.AcceptLanguages))
+	for _, tag := range appCfg.AcceptLanguages {
+		if tag.String() != lang {
+			alternates = append(alternates, OpenGraphLocale(tag))
+		}
+	}
+	return locale, alternates, nil
+}
+
+// OpenGraphMeta renders the og:locale and og:locale:alternate meta tags for
+// use in html/template views. Renders nothing if the middleware is missing.
+func OpenGraphMeta(c echo.Context) template.HTML {
+	locale, alternates, err := OpenGraphLocales(c)
+	if err != nil {
+		return ""
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<meta property="og:locale" content="%s">`, html.EscapeString(locale))
+	for _, alternate := range alternates {
+		fmt.Fprintf(&b, "\n"+`<meta property="og:locale:alternate" content="%s">`, html.EscapeString(alternate))
+	}
+	return template.HTML(b.String())
+}
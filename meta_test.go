@@ -0,0 +1,33 @@
+package echoi18n
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// TestOpenGraphMeta tests the rendered Open Graph locale tags.
+func TestOpenGraphMeta(t *testing.T) {
+	t.Parallel()
+	app := echo.New()
+	app.Use(NewMiddleware(&Config{}))
+	app.GET("/", func(c echo.Context) error {
+		return c.String(http.StatusOK, string(OpenGraphMeta(c)))
+	})
+
+	got, err := makeRequest(language.Chinese, "", app)
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(got.Body)
+	assert.Equal(t, `<meta property="og:locale" content="zh_CN">
+<meta property="og:locale:alternate" content="en_US">`, string(body))
+}
+
+// TestOpenGraphLocale tests formatting tags as Open Graph locales.
+func TestOpenGraphLocale(t *testing.T) {
+	assert.Equal(t, "en_GB", OpenGraphLocale(language.BritishEnglish))
+	assert.Equal(t, "pt_BR", OpenGraphLocale(language.Portuguese))
+}
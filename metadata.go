@@ -0,0 +1,140 @@
+package echoi18n
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// Metadata holds the custom fields of a message that go-i18n ignores, such as
+// its workflow state.
+type Metadata map[string]string
+
+// reservedMessageKeys are the message keys interpreted by go-i18n.
+var reservedMessageKeys = map[string]bool{
+	"id": true, "description": true, "hash": true, "leftdelim": true, "rightdelim": true,
+	"zero": true, "one": true, "two": true, "few": true, "many": true, "other": true,
+	"translation": true,
+}
+
+// loadMetadata parses the custom message fields of a message file.
+func (c *Config) loadMetadata(lang, format string, buf []byte) error {
+	if len(buf) == 0 {
+		return nil
+	}
+	unmarshal, ok := c.unmarshalFuncs()[format]
+	if !ok {
+		return fmt.Errorf("no unmarshaler registered for %s", format)
+	}
+	var raw interface{}
+	if err := unmarshal(buf, &raw); err != nil {
+		return err
+	}
+
+	if c.metadata[lang] == nil {
+		c.metadata[lang] = map[string]Metadata{}
+	}
+	collected := map[string]Metadata{}
+	collectMetadata(raw, "", collected)
+	for id, fields := range collected {
+		c.metadata[lang][c.normalizeID(id)] = fields
+	}
+	return nil
+}
+
+// stringKeyMap converts decoded maps to map[string]interface{}.
+func stringKeyMap(raw interface{}) (map[string]interface{}, bool) {
+	switch data := raw.(type) {
+	case map[string]interface{}:
+		return data, true
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(data))
+		for k, v := range data {
+			m[fmt.Sprint(k)] = v
+		}
+		return m, true
+	}
+	return nil, false
+}
+
+// isMessageMap reports whether the map is a message rather than a map of
+// nested messages, following go-i18n's rules.
+func isMessageMap(data map[string]interface{}) bool {
+	for key, value := range data {
+		if _, ok := value.(string); ok && reservedMessageKeys[strings.ToLower(key)] && key != "translation" {
+			return true
+		}
+	}
+	return false
+}
+
+// collectMetadata walks decoded message file data and records the custom
+// fields of every message, using the same nested ID rules as go-i18n.
+func collectMetadata(raw interface{}, prefix string, metadata map[string]Metadata) {
+	data, ok := stringKeyMap(raw)
+	if !ok {
+		return
+	}
+	for key, value := range data {
+		child, ok := stringKeyMap(value)
+		if !ok {
+			continue
+		}
+		id := prefix + key
+		if !isMessageMap(child) {
+			collectMetadata(child, id+".", metadata)
+			continue
+		}
+		if customID, ok := child["id"].(string); ok && customID != "" {
+			id = customID
+		}
+		fields := Metadata{}
+		for k, v := range child {
+			if !reservedMessageKeys[strings.ToLower(k)] && v != nil {
+				fields[k] = fmt.Sprint(v)
+			}
+		}
+		if len(fields) > 0 {
+			metadata[id] = fields
+		}
+	}
+}
+
+// messageIndex is the loaded messages of a language keyed by ID.
+type messageIndex map[string]*i18n.Message
+
+// initIndex indexes the loaded messages of each language by ID.
+func (c *Config) initIndex() {
+	c.index = make(map[string]messageIndex, len(c.messages))
+	for lang, messages := range c.messages {
+		c.index[lang] = indexMessages(messages)
+	}
+}
+
+// MessageInfo is the metadata of a loaded message.
+type MessageInfo struct {
+	ID          string   `json:"id"`
+	Description string   `json:"description,omitempty"`
+	Hash        string   `json:"hash,omitempty"`
+	Fields      Metadata `json:"fields,omitempty"` // Custom fields, e.g. a maximum length or a tone hint.
+}
+
+// Message returns the metadata of a message of the main catalog of lang, to
+// enforce per-message rules or show hints to translators at runtime.
+func (c *Config) Message(lang, id string) (MessageInfo, error) {
+	c = c.active()
+	id = c.normalizeID(id)
+	m := c.index[lang][id]
+	if m == nil {
+		return MessageInfo{}, fmt.Errorf("i18n.Message error: message %q not found in language %q", id, lang)
+	}
+	info := MessageInfo{ID: m.ID, Description: m.Description, Hash: m.Hash}
+	if fields := c.metadata[lang][id]; len(fields) > 0 {
+		info.Fields = make(Metadata, len(fields))
+		for k, v := range fields {
+			info.Fields[k] = v
+		}
+	}
+	return info, nil
+}
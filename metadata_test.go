@@ -0,0 +1,88 @@
+package echoi18n
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+)
+
+// Test_collectMetadata tests extracting custom message fields.
+func Test_collectMetadata(t *testing.T) {
+	var raw interface{}
+	assert.NoError(t, yaml.Unmarshal([]byte(`
+welcome: hello
+checkout:
+  title:
+    other: Checkout
+    state: draft
+  pay:
+    id: payNow
+    other: Pay now
+    maxLength: "12"
+`), &raw))
+
+	metadata := map[string]Metadata{}
+	collectMetadata(raw, "", metadata)
+	assert.Equal(t, map[string]Metadata{
+		"checkout.title": {"state": "draft"},
+		"payNow":         {"maxLength": "12"},
+	}, metadata)
+}
+
+// TestConfig_Message tests reading the metadata of a message.
+func TestConfig_Message(t *testing.T) {
+	t.Parallel()
+	cfg := &Config{
+		AcceptLanguages: []language.Tag{language.English},
+		RootPath:        "localize",
+		Loader: LoaderFunc(func(path string) ([]byte, error) {
+			return []byte(`
+welcome:
+  description: Greeting shown on the home page
+  other: hello
+  maxLength: "20"
+  tone: friendly
+bye: goodbye
+`), nil
+		}),
+	}
+	app := newAdminServer(cfg)
+
+	info, err := cfg.Message("en", "welcome")
+	assert.NoError(t, err)
+	assert.Equal(t, MessageInfo{
+		ID:          "welcome",
+		Description: "Greeting shown on the home page",
+		Fields:      Metadata{"maxLength": "20", "tone": "friendly"},
+	}, info)
+
+	info, err = cfg.Message("en", "bye")
+	assert.NoError(t, err)
+	assert.Equal(t, MessageInfo{ID: "bye"}, info)
+
+	_, err = cfg.Message("zh", "welcome")
+	assert.EqualError(t, err, `i18n.Message error: message "welcome" not found in language "zh"`)
+
+	got, err := makeRequest(language.Und, "admin/message/en/welcome", app)
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(got.Body)
+	assert.JSONEq(t, `{"id": "welcome", "description": "Greeting shown on the home page", "fields": {"maxLength": "20", "tone": "friendly"}}`, string(body))
+
+	got, err = makeRequest(language.Und, "admin/message/en/missing", app)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, got.StatusCode)
+}
+
+// BenchmarkConfig_Message measures reading the metadata of a message.
+func BenchmarkConfig_Message(b *testing.B) {
+	cfg := &Config{}
+	NewMiddleware(cfg)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = cfg.Message("en", "welcome")
+	}
+}
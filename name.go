@@ -0,0 +1,83 @@
+package echoi18n
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/labstack/echo/v4"
+	"golang.org/x/text/language"
+)
+
+// PersonName is a personal name with an optional honorific such as "Dr." or "様".
+type PersonName struct {
+	Given     string
+	Family    string
+	Honorific string
+}
+
+// NameFormatter formats a personal name for a language.
+type NameFormatter func(lang language.Tag, name PersonName) string
+
+// familyFirstLanguages write the family name before the given name.
+var familyFirstLanguages = map[string]bool{"zh": true, "ja": true, "ko": true, "hu": true, "vi": true}
+
+// honorificSuffixLanguages place honorifics after the name.
+var honorificSuffixLanguages = map[string]bool{"zh": true, "ja": true, "ko": true}
+
+// isCJK reports whether s is written without spaces between name parts.
+func isCJK(s string) bool {
+	for _, r := range s {
+		if unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana) {
+			return true
+		}
+	}
+	return false
+}
+
+// joinName joins name parts, without spaces for names in CJK scripts.
+func joinName(parts ...string) string {
+	var nonEmpty []string
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			nonEmpty = append(nonEmpty, part)
+		}
+	}
+	sep := " "
+	if isCJK(strings.Join(nonEmpty, "")) {
+		sep = ""
+	}
+	return strings.Join(nonEmpty, sep)
+}
+
+// defaultFormatName orders name parts following the conventions of lang:
+// family name first for zh, ja, ko, hu and vi, and honorifics after the name
+// for zh, ja and ko.
+func defaultFormatName(lang language.Tag, name PersonName) string {
+	base, _ := lang.Base()
+	full := joinName(name.Given, name.Family)
+	if familyFirstLanguages[base.String()] {
+		full = joinName(name.Family, name.Given)
+	}
+	if name.Honorific == "" {
+		return full
+	}
+	if honorificSuffixLanguages[base.String()] {
+		return joinName(full, name.Honorific)
+	}
+	return joinName(name.Honorific, full)
+}
+
+// FormatName formats a given and family name for the negotiated language.
+func FormatName(c echo.Context, given, family string) string {
+	return FormatPersonName(c, PersonName{Given: given, Family: family})
+}
+
+// FormatPersonName formats a personal name for the negotiated language with
+// Config.NameFormatter, which places honorifics and orders the name parts.
+func FormatPersonName(c echo.Context, name PersonName) string {
+	appCfg, err := getConfig(c)
+	if err != nil {
+		return defaultFormatName(language.Und, name)
+	}
+	return appCfg.NameFormatter(language.Make(appCfg.language(c)), name)
+}
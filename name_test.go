@@ -0,0 +1,60 @@
+package echoi18n
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// Test_defaultFormatName tests name order and honorific placement.
+func Test_defaultFormatName(t *testing.T) {
+	tests := []struct {
+		lang language.Tag
+		name PersonName
+		want string
+	}{
+		{language.English, PersonName{Given: "Alex", Family: "Smith"}, "Alex Smith"},
+		{language.English, PersonName{Given: "Alex", Family: "Smith", Honorific: "Dr."}, "Dr. Alex Smith"},
+		{language.Hungarian, PersonName{Given: "János", Family: "Nagy"}, "Nagy János"},
+		{language.Chinese, PersonName{Given: "三", Family: "张"}, "张三"},
+		{language.Chinese, PersonName{Family: "王", Honorific: "先生"}, "王先生"},
+		{language.Japanese, PersonName{Given: "太郎", Family: "山田", Honorific: "様"}, "山田太郎様"},
+		{language.Chinese, PersonName{Given: "Alex", Family: "Smith"}, "Smith Alex"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, defaultFormatName(tt.lang, tt.name))
+		})
+	}
+}
+
+// TestFormatName tests names for the negotiated language and custom formatters.
+func TestFormatName(t *testing.T) {
+	t.Parallel()
+	app := echo.New()
+	app.Use(NewMiddleware(&Config{}))
+	app.GET("/", func(c echo.Context) error {
+		return c.String(http.StatusOK, FormatName(c, "三", "张"))
+	})
+
+	got, err := makeRequest(language.Chinese, "", app)
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(got.Body)
+	assert.Equal(t, "张三", string(body))
+
+	custom := echo.New()
+	custom.Use(NewMiddleware(&Config{NameFormatter: func(lang language.Tag, name PersonName) string {
+		return name.Family + ", " + name.Given
+	}}))
+	custom.GET("/", func(c echo.Context) error {
+		return c.String(http.StatusOK, FormatName(c, "Alex", "Smith"))
+	})
+	got, err = makeRequest(language.English, "", custom)
+	assert.NoError(t, err)
+	body, _ = io.ReadAll(got.Body)
+	assert.Equal(t, "Smith, Alex", string(body))
+}
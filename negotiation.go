@@ -0,0 +1,106 @@
+package echoi18n
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"golang.org/x/text/language"
+)
+
+// NegotiationContextKey is the Echo Context key of the request Negotiation.
+const NegotiationContextKey = "echoi18n.negotiation"
+
+// defaultMediaTypes are the representations offered by default, JSON first.
+var defaultMediaTypes = []string{echo.MIMEApplicationJSON, echo.MIMETextHTML, echo.MIMETextPlain}
+
+// Negotiation is the language and representation chosen for a response.
+type Negotiation struct {
+	Language  language.Tag // Negotiated language.
+	MediaType string       // Negotiated media type, one of Config.MediaTypes.
+}
+
+// acceptRange is a media range of the Accept header and its quality.
+type acceptRange struct {
+	mediaType string
+	quality   float64
+}
+
+// parseAccept parses the media ranges of an Accept header.
+func parseAccept(header string) []acceptRange {
+	var ranges []acceptRange
+	for _, part := range strings.Split(header, ",") {
+		mediaType, params, _ := strings.Cut(part, ";")
+		r := acceptRange{mediaType: strings.ToLower(strings.TrimSpace(mediaType)), quality: 1}
+		if r.mediaType == "" {
+			continue
+		}
+		for _, param := range strings.Split(params, ";") {
+			name, value, _ := strings.Cut(param, "=")
+			if strings.TrimSpace(name) != "q" {
+				continue
+			}
+			if q, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+				r.quality = q
+			}
+		}
+		ranges = append(ranges, r)
+	}
+	return ranges
+}
+
+// mediaQuality returns the quality of a media type given by its most specific
+// matching range: the type itself, then "type/*", then "*/*".
+func mediaQuality(ranges []acceptRange, mediaType string) float64 {
+	mainType, _, _ := strings.Cut(mediaType, "/")
+	quality, specificity := 0.0, -1
+	for _, r := range ranges {
+		s := -1
+		switch r.mediaType {
+		case mediaType:
+			s = 2
+		case mainType + "/*":
+			s = 1
+		case "*/*":
+			s = 0
+		}
+		if s > specificity {
+			quality, specificity = r.quality, s
+		}
+	}
+	return quality
+}
+
+// mediaType returns the offered media type with the highest quality in the
+// Accept header, the first one on ties or without header.
+func (c *Config) mediaType(r *http.Request) string {
+	header := r.Header.Get(echo.HeaderAccept)
+	if header == "" {
+		return c.MediaTypes[0]
+	}
+	ranges := parseAccept(header)
+	best, bestQuality := c.MediaTypes[0], 0.0
+	for _, mediaType := range c.MediaTypes {
+		if q := mediaQuality(ranges, mediaType); q > bestQuality {
+			best, bestQuality = mediaType, q
+		}
+	}
+	return best
+}
+
+// Negotiate returns the language and representation of the response, so
+// handlers, renderers and error handlers make consistent choices. The result
+// is kept in the context under NegotiationContextKey.
+func Negotiate(c echo.Context) Negotiation {
+	if negotiation, ok := c.Get(NegotiationContextKey).(Negotiation); ok {
+		return negotiation
+	}
+	appCfg, err := getConfig(c)
+	if err != nil {
+		return Negotiation{Language: language.Und, MediaType: defaultMediaTypes[0]}
+	}
+	negotiation := Negotiation{Language: language.Make(appCfg.language(c)), MediaType: appCfg.mediaType(c.Request())}
+	c.Set(NegotiationContextKey, negotiation)
+	return negotiation
+}
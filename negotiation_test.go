@@ -0,0 +1,88 @@
+package echoi18n
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// TestNegotiate tests negotiating the language and representation together.
+func TestNegotiate(t *testing.T) {
+	t.Parallel()
+	app := echo.New()
+	app.Use(NewMiddleware(&Config{}))
+	app.GET("/", func(c echo.Context) error {
+		negotiation := Negotiate(c)
+		assert.Equal(t, negotiation, c.Get(NegotiationContextKey))
+		return c.String(http.StatusOK, negotiation.Language.String()+" "+negotiation.MediaType)
+	})
+	xml := echo.New()
+	xml.Use(NewMiddleware(&Config{MediaTypes: []string{echo.MIMEApplicationXML, echo.MIMEApplicationJSON}}))
+	xml.GET("/", func(c echo.Context) error {
+		return c.String(http.StatusOK, Negotiate(c).MediaType)
+	})
+
+	tests := []struct {
+		name   string
+		app    *echo.Echo
+		lang   string
+		accept string
+		want   string
+	}{
+		{"defaults", app, "", "", "en application/json"},
+		{"browser", app, "zh", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", "zh text/html"},
+		{"quality", app, "zh", "application/json;q=0.5, text/plain", "zh text/plain"},
+		{"wildcard subtype", app, "en", "text/*", "en text/html"},
+		{"specific range wins", app, "en", "text/*;q=0.9, text/html;q=0.1", "en text/plain"},
+		{"unacceptable", app, "en", "image/png", "en application/json"},
+		{"custom media types", xml, "", "*/*", echo.MIMEApplicationXML},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Accept-Language", tt.lang)
+			req.Header.Set(echo.HeaderAccept, tt.accept)
+			rec := httptest.NewRecorder()
+			tt.app.ServeHTTP(rec, req)
+			assert.Equal(t, tt.want, rec.Body.String())
+		})
+	}
+
+	c := app.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+	assert.Equal(t, Negotiation{Language: language.Und, MediaType: echo.MIMEApplicationJSON}, Negotiate(c))
+}
+
+// TestHTTPErrorHandler_negotiated tests answering Localize errors in the negotiated representation.
+func TestHTTPErrorHandler_negotiated(t *testing.T) {
+	t.Parallel()
+	app := newErrorServer(&Config{
+		Bundles: []Bundle{{"zh": {{ID: "echoi18n.error", Other: "<出错了>"}}}},
+	})
+
+	tests := []struct {
+		name   string
+		accept string
+		want   string
+	}{
+		{"plain", echo.MIMETextPlain, "<出错了>"},
+		{"html", echo.MIMETextHTML, "&lt;出错了&gt;"},
+		{"json", echo.MIMEApplicationJSON, `{"message":"\u003c出错了\u003e"}` + "\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Accept-Language", "zh")
+			req.Header.Set(echo.HeaderAccept, tt.accept)
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+			assert.Equal(t, http.StatusInternalServerError, rec.Code)
+			body, _ := io.ReadAll(rec.Body)
+			assert.Equal(t, tt.want, string(body))
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package echoi18n
+
+import (
+	"fmt"
+
+	"github.com/labstack/echo/v4"
+	"golang.org/x/text/language"
+)
+
+// languageKey is the Echo Context key of the language set by SetLanguage.
+const languageKey = "echoi18n.language"
+
+// LanguageContextKey is the Echo Context key of the request language kept by
+// GetLanguage.
+const LanguageContextKey = "echoi18n.languageTag"
+
+// SetLanguage overrides the negotiated language for the remainder of the
+// request, e.g. with the user's preference once known after authentication.
+// Localize calls and the response headers of the middleware use it.
+func SetLanguage(c echo.Context, tag language.Tag) error {
+	appCfg, err := getConfig(c)
+	if err != nil {
+		return fmt.Errorf("i18n.SetLanguage error: %v", err)
+	}
+	lang := tag.String()
+	if _, ok := appCfg.localizerMap.Load(lang); !ok {
+		return fmt.Errorf("i18n.SetLanguage error: language %q is not supported", lang)
+	}
+	c.Set(languageKey, lang)
+	languageChanged(c)
+	return nil
+}
+
+// overriddenLanguage returns the language set by SetLanguage, if any.
+func overriddenLanguage(c echo.Context) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	lang, ok := c.Get(languageKey).(string)
+	return lang, ok
+}
+
+// GetLanguage returns the language of the request, negotiated or set by
+// SetLanguage, so handlers can sort, format dates or segment analytics by
+// locale without running the LangHandler again. It is kept in the context
+// under LanguageContextKey. Returns language.Und without the middleware.
+func GetLanguage(c echo.Context) language.Tag {
+	if tag, ok := c.Get(LanguageContextKey).(language.Tag); ok {
+		return tag
+	}
+	appCfg, err := getConfig(c)
+	if err != nil {
+		return language.Und
+	}
+	tag := language.Make(appCfg.resolve(c).lang)
+	c.Set(LanguageContextKey, tag)
+	return tag
+}
@@ -0,0 +1,81 @@
+package echoi18n
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// TestSetLanguage tests overriding the negotiated language mid-chain.
+func TestSetLanguage(t *testing.T) {
+	t.Parallel()
+	app := echo.New()
+	app.Use(NewMiddleware(&Config{DebugHeaders: true, CacheKeyHeader: HeaderCacheKey}))
+	authenticate := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if user := c.QueryParam("user"); user != "" {
+				if err := SetLanguage(c, language.Make(user)); err != nil {
+					return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+				}
+			}
+			return next(c)
+		}
+	}
+	app.GET("/", func(c echo.Context) error {
+		return c.String(http.StatusOK, MustLocalize(c, "welcome"))
+	}, authenticate)
+
+	tests := []struct {
+		name       string
+		url        string
+		code       int
+		want       string
+		wantSource string
+	}{
+		{"negotiated", "/", http.StatusOK, "hello", SourceDefault},
+		{"override", "/?user=zh", http.StatusOK, "你好", SourceOverride},
+		{"override query", "/?lang=en&user=zh", http.StatusOK, "你好", SourceOverride},
+		{"unsupported", "/?user=fr", http.StatusBadRequest, `{"message":"i18n.SetLanguage error: language \"fr\" is not supported"}` + "\n", SourceDefault},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.url, nil))
+			assert.Equal(t, tt.code, rec.Code)
+			body, _ := io.ReadAll(rec.Body)
+			assert.Equal(t, tt.want, string(body))
+			assert.Equal(t, tt.wantSource, rec.Header().Get(HeaderSource))
+			assert.Equal(t, rec.Header().Get(HeaderLanguage), rec.Header().Get(HeaderCacheKey))
+		})
+	}
+
+	c := app.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+	assert.EqualError(t, SetLanguage(c, language.Chinese), "i18n.SetLanguage error: Config is nil")
+}
+
+// TestGetLanguage tests exposing the request language to handlers.
+func TestGetLanguage(t *testing.T) {
+	t.Parallel()
+	app := echo.New()
+	app.Use(NewMiddleware())
+	app.GET("/", func(c echo.Context) error {
+		negotiated := GetLanguage(c)
+		if err := SetLanguage(c, language.English); err != nil {
+			return err
+		}
+		return c.String(http.StatusOK, negotiated.String()+" "+GetLanguage(c).String())
+	})
+
+	got, err := makeRequest(language.Chinese, "", app)
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(got.Body)
+	assert.Equal(t, "zh en", string(body))
+
+	c := app.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+	assert.Equal(t, language.Und, GetLanguage(c))
+}
@@ -0,0 +1,156 @@
+package echoi18n
+
+import (
+	"fmt"
+	"sync"
+	texttemplate "text/template"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/nicksnyder/go-i18n/v2/i18n/template"
+)
+
+// messageParser parses message templates with the functions configured for
+// the bundle. Its parsed templates are cached since the functions are fixed:
+// go-i18n parses each message template once, on first use, and keeps it.
+// Each catalog snapshot has its own parser, so the templates compiled at load
+// are released with the snapshot.
+type messageParser struct {
+	text       *template.TextParser
+	sandbox    *sandbox
+	leftDelim  string
+	rightDelim string
+	rawStrings bool
+	compiled   sync.Map // Templates compiled at load and not used yet, keyed by templateKey.
+}
+
+// templateKey identifies a message template compiled at load.
+type templateKey struct {
+	src, leftDelim, rightDelim string
+}
+
+// compiledTemplate is the result of compiling a message template.
+type compiledTemplate struct {
+	parsed template.ParsedTemplate
+	err    error
+}
+
+// newMessageParser creates the message template parser of a Config.
+func (c *Config) newMessageParser() *messageParser {
+	funcs := texttemplate.FuncMap{}
+	if c.SprigFuncs {
+		for name, fn := range sprigFuncs {
+			funcs[name] = fn
+		}
+	}
+	for name, fn := range c.Funcs {
+		funcs[name] = fn
+	}
+	return &messageParser{
+		text:       &template.TextParser{Funcs: funcs},
+		sandbox:    c.newSandbox(),
+		leftDelim:  c.LeftDelim,
+		rightDelim: c.RightDelim,
+		rawStrings: c.RawStrings,
+	}
+}
+
+// forSnapshot returns a parser with the settings of p and no compiled
+// templates, for a new catalog snapshot.
+func (p *messageParser) forSnapshot() *messageParser {
+	return &messageParser{
+		text:       p.text,
+		sandbox:    p.sandbox,
+		leftDelim:  p.leftDelim,
+		rightDelim: p.rightDelim,
+		rawStrings: p.rawStrings,
+	}
+}
+
+// Cacheable reports that parsed templates can be cached.
+func (p *messageParser) Cacheable() bool {
+	return true
+}
+
+// Parse returns the template compiled at load, handing it over to the cache
+// of go-i18n, or parses the message template.
+func (p *messageParser) Parse(src, leftDelim, rightDelim string) (template.ParsedTemplate, error) {
+	if compiled, ok := p.compiled.LoadAndDelete(templateKey{src, leftDelim, rightDelim}); ok {
+		return compiled.(*compiledTemplate).parsed, compiled.(*compiledTemplate).err
+	}
+	return p.parse(src, leftDelim, rightDelim)
+}
+
+// compile parses a message template and keeps it for its first use.
+func (p *messageParser) compile(src, leftDelim, rightDelim string) error {
+	parsed, err := p.parse(src, leftDelim, rightDelim)
+	p.compiled.Store(templateKey{src, leftDelim, rightDelim}, &compiledTemplate{parsed: parsed, err: err})
+	return err
+}
+
+// parse parses a message template. Messages without their own delimiters are
+// converted from the bundle's custom delimiters first.
+func (p *messageParser) parse(src, leftDelim, rightDelim string) (template.ParsedTemplate, error) {
+	if p.isRaw(src, leftDelim) {
+		return rawTemplate(src), nil
+	}
+	if leftDelim == "" && p.leftDelim != "" && p.rightDelim != "" {
+		src = convertDelims(src, p.leftDelim, p.rightDelim, p.text.Funcs)
+	}
+	if p.sandbox != nil {
+		if err := p.sandbox.check(src, leftDelim, rightDelim); err != nil {
+			return nil, err
+		}
+	}
+	return p.text.Parse(src, leftDelim, rightDelim)
+}
+
+// pluralForms returns the non-empty plural forms of a message.
+func pluralForms(m *i18n.Message) []string {
+	var forms []string
+	for _, src := range []string{m.Zero, m.One, m.Two, m.Few, m.Many, m.Other} {
+		if src != "" {
+			forms = append(forms, src)
+		}
+	}
+	return forms
+}
+
+// validateTemplates compiles every loaded message template, reporting syntax
+// errors and functions missing from Funcs at load instead of at request time.
+func (c *Config) validateTemplates() Diagnostics {
+	var diagnostics Diagnostics
+	for _, lang := range c.catalogLanguages() {
+		for _, m := range c.messages[lang] {
+			for _, src := range pluralForms(m) {
+				if err := c.parser.compile(src, m.LeftDelim, m.RightDelim); err != nil {
+					diagnostics = append(diagnostics, Diagnostic{
+						Severity:  SeverityError,
+						Lang:      lang,
+						MessageID: m.ID,
+						Message:   fmt.Sprintf("message %q in language %q: %v", m.ID, lang, err),
+					})
+					break
+				}
+			}
+		}
+	}
+	return diagnostics
+}
+
+// withParser returns the localize config using the bundle's template parser.
+// The functions or parser the caller configured are kept, sandboxed when
+// AllowedFuncs is set.
+func (c *Config) withParser(lc *i18n.LocalizeConfig) *i18n.LocalizeConfig {
+	withParser := *lc
+	switch {
+	case lc.TemplateParser == nil && lc.Funcs == nil:
+		withParser.TemplateParser = c.parser
+	case c.parser.sandbox == nil:
+		return lc
+	case lc.TemplateParser != nil:
+		withParser.TemplateParser = &sandboxedParser{Parser: lc.TemplateParser, sandbox: c.parser.sandbox}
+	default:
+		withParser.TemplateParser = &sandboxedParser{Parser: &template.TextParser{Funcs: lc.Funcs}, sandbox: c.parser.sandbox}
+	}
+	return &withParser
+}
@@ -0,0 +1,79 @@
+package echoi18n
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// TestConfig_Funcs tests custom functions in message templates.
+func TestConfig_Funcs(t *testing.T) {
+	t.Parallel()
+	app := echo.New()
+	app.Use(NewMiddleware(&Config{
+		Funcs:   template.FuncMap{"upper": strings.ToUpper},
+		Bundles: []Bundle{{"en": {{ID: "shout", Other: "{{ upper .name }}!"}}}},
+	}))
+	app.GET("/:name", func(c echo.Context) error {
+		return c.String(http.StatusOK, MustLocalize(c, &i18n.LocalizeConfig{
+			MessageID:    "shout",
+			TemplateData: map[string]string{"name": c.Param("name")},
+		}))
+	})
+
+	got, err := makeRequest(language.English, "alex", app)
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(got.Body)
+	assert.Equal(t, "ALEX!", string(body))
+}
+
+// TestConfig_validateTemplates tests rejecting unknown functions at load.
+func TestConfig_validateTemplates(t *testing.T) {
+	t.Parallel()
+	assert.PanicsWithError(t, `i18n.NewMiddleware error: en: message "shout" in language "en": template: :1: function "upper" not defined`, func() {
+		NewMiddleware(&Config{
+			Bundles: []Bundle{{"en": {{ID: "shout", Other: "{{ upper .name }}!"}}}},
+		})
+	})
+}
+
+// TestConfig_LazyTemplates tests compiling templates at load or on first use.
+func TestConfig_LazyTemplates(t *testing.T) {
+	t.Parallel()
+	bundles := []Bundle{{"en": {{ID: "shout", Other: "{{ upper .name }}!"}, {ID: "greet", Other: "hi {{ .name }}"}}}}
+	eager := &Config{Funcs: template.FuncMap{"upper": strings.ToUpper}, Bundles: bundles}
+	NewMiddleware(eager)
+	snapshot := eager.active()
+	_, ok := snapshot.parser.compiled.Load(templateKey{src: "hi {{ .name }}"})
+	assert.True(t, ok)
+	assert.NoError(t, eager.Reload())
+	assert.NotSame(t, snapshot.parser, eager.active().parser)
+
+	eagerCtx := echo.New().NewContext(nil, nil)
+	eagerCtx.Set(localsKey, &resolution{catalog: eager})
+	message, err := Localize(eagerCtx, &i18n.LocalizeConfig{MessageID: "greet", TemplateData: map[string]string{"name": "alex"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "hi alex", message)
+	_, ok = eager.active().parser.compiled.Load(templateKey{src: "hi {{ .name }}"})
+	assert.False(t, ok, "handed over to go-i18n on first use")
+
+	lazy := &Config{Bundles: bundles, LazyTemplates: true}
+	assert.NotPanics(t, func() { NewMiddleware(lazy) })
+	_, ok = lazy.active().parser.compiled.Load(templateKey{src: "hi {{ .name }}"})
+	assert.False(t, ok)
+
+	c := echo.New().NewContext(nil, nil)
+	c.Set(localsKey, &resolution{catalog: lazy})
+	message, err = Localize(c, &i18n.LocalizeConfig{MessageID: "greet", TemplateData: map[string]string{"name": "alex"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "hi alex", message)
+	_, err = Localize(c, "shout")
+	assert.ErrorContains(t, err, `function "upper" not defined`)
+}
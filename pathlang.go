@@ -0,0 +1,87 @@
+package echoi18n
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// pathLanguageKey is the Echo Context key of the language of the path prefix
+// stripped by StripLanguagePrefix.
+const pathLanguageKey = "echoi18n.pathLanguage"
+
+// languagePrefix is the path prefix of a supported language.
+type languagePrefix struct {
+	prefix string
+	lang   string
+}
+
+// initPrefixes computes the path prefixes of the supported languages, longest
+// first so "/zh-Hant" is matched before "/zh".
+func (c *Config) initPrefixes() {
+	prefixes := make([]languagePrefix, 0, len(c.AcceptLanguages))
+	for _, tag := range c.AcceptLanguages {
+		prefixes = append(prefixes, languagePrefix{prefix: c.PathPrefix(tag.String()), lang: tag.String()})
+	}
+	sort.SliceStable(prefixes, func(i, j int) bool {
+		return len(prefixes[i].prefix) > len(prefixes[j].prefix)
+	})
+	c.prefixes = prefixes
+}
+
+// pathLanguage returns the language of the leading path segment and the path
+// without it, or false if the path has no language prefix.
+func (c *Config) pathLanguage(path string) (string, string, bool) {
+	for _, p := range c.prefixes {
+		if !strings.HasPrefix(path, p.prefix) {
+			continue
+		}
+		rest := path[len(p.prefix):]
+		if rest == "" {
+			return p.lang, "/", true
+		}
+		if rest[0] == '/' {
+			return p.lang, rest, true
+		}
+	}
+	return "", "", false
+}
+
+// StripLanguagePrefix returns a middleware detecting the language from the
+// leading path segment and removing it before routing, so "/zh/products" is
+// served in Chinese by the "/products" route. It must be registered with
+// Echo#Pre on the Config passed to NewMiddleware:
+//
+//	e.Pre(cfg.StripLanguagePrefix())
+//	e.Use(echoi18n.NewMiddleware(cfg))
+func (c *Config) StripLanguagePrefix() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			req := ctx.Request()
+			lang, rest, ok := c.active().pathLanguage(req.URL.Path)
+			if !ok {
+				return next(ctx)
+			}
+			ctx.Set(pathLanguageKey, lang)
+			if req.URL.RawPath != "" {
+				_, req.URL.RawPath, _ = c.active().pathLanguage(req.URL.RawPath)
+			}
+			req.URL.Path = rest
+			return next(ctx)
+		}
+	}
+}
+
+// requestPathLanguage returns the language of the request path prefix,
+// stripped by StripLanguagePrefix or detected with PathLanguage.
+func (c *Config) requestPathLanguage(ctx echo.Context) (string, bool) {
+	if lang, ok := ctx.Get(pathLanguageKey).(string); ok {
+		return lang, true
+	}
+	if !c.PathLanguage {
+		return "", false
+	}
+	lang, _, ok := c.pathLanguage(ctx.Request().URL.Path)
+	return lang, ok
+}
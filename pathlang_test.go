@@ -0,0 +1,72 @@
+package echoi18n
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// TestConfig_StripLanguagePrefix tests routing language-prefixed paths.
+func TestConfig_StripLanguagePrefix(t *testing.T) {
+	t.Parallel()
+	cfg := &Config{
+		AcceptLanguages: []language.Tag{language.English, language.Chinese, language.TraditionalChinese},
+		Bundles:         []Bundle{{"zh-Hant": {{ID: "welcome", Other: "妳好"}}}},
+		DebugHeaders:    true,
+	}
+	app := echo.New()
+	app.Pre(cfg.StripLanguagePrefix())
+	app.Use(NewMiddleware(cfg))
+	handler := func(c echo.Context) error {
+		return c.String(http.StatusOK, c.Request().URL.Path+" "+MustLocalize(c, "welcome"))
+	}
+	app.GET("/", handler)
+	app.GET("/products", handler)
+
+	tests := []struct {
+		name       string
+		url        string
+		wantStatus int
+		wantBody   string
+		wantSource string
+	}{
+		{"prefix", "/zh/products", http.StatusOK, "/products 你好", SourcePath},
+		{"longest prefix", "/zh-Hant/products", http.StatusOK, "/products 妳好", SourcePath},
+		{"home", "/zh", http.StatusOK, "/ 你好", SourcePath},
+		{"no prefix", "/products", http.StatusOK, "/products hello", SourceDefault},
+		{"partial segment", "/zhx/products", http.StatusNotFound, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus == http.StatusOK {
+				body, _ := io.ReadAll(rec.Body)
+				assert.Equal(t, tt.wantBody, string(body))
+				assert.Equal(t, tt.wantSource, rec.Header().Get(HeaderSource))
+			}
+		})
+	}
+}
+
+// TestConfig_PathLanguage tests detecting the language of prefixed routes.
+func TestConfig_PathLanguage(t *testing.T) {
+	t.Parallel()
+	app := echo.New()
+	app.Use(NewMiddleware(&Config{PathLanguage: true}))
+	app.GET("/:lang/products", func(c echo.Context) error {
+		return c.String(http.StatusOK, MustLocalize(c, "welcome"))
+	})
+
+	got, err := makeRequest(language.English, "zh/products", app)
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(got.Body)
+	assert.Equal(t, "你好", string(body))
+}
@@ -0,0 +1,139 @@
+package echoi18n
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"golang.org/x/text/language"
+)
+
+// defaultCookieMaxAge is the default lifetime of the language cookie.
+const defaultCookieMaxAge = 365 * 24 * time.Hour
+
+// defaultPersistLanguage stores the selected language in the language cookie.
+func defaultPersistLanguage(c echo.Context, lang string) error {
+	appCfg, err := getConfig(c)
+	if err != nil {
+		return err
+	}
+	c.SetCookie(appCfg.languageCookie(lang))
+	return nil
+}
+
+// languageCookie returns the language cookie remembering lang.
+func (c *Config) languageCookie(lang string) *http.Cookie {
+	return &http.Cookie{
+		Name:     c.CookieName,
+		Value:    lang,
+		Path:     c.CookiePath,
+		MaxAge:   int(c.CookieMaxAge / time.Second),
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+// SetLanguageCookie remembers a supported language as the explicit choice of
+// the user in the language cookie, read by the default LangHandler on the
+// next requests. Use SetLanguage to serve it for the current request too.
+func SetLanguageCookie(c echo.Context, tag language.Tag) error {
+	appCfg, err := getConfig(c)
+	if err != nil {
+		return fmt.Errorf("i18n.SetLanguageCookie error: %v", err)
+	}
+	lang := tag.String()
+	if _, ok := appCfg.localizerMap.Load(lang); !ok {
+		return fmt.Errorf("i18n.SetLanguageCookie error: language %q is not supported", lang)
+	}
+	c.SetCookie(appCfg.languageCookie(lang))
+	return nil
+}
+
+// RememberLanguage returns a middleware that persists a supported language
+// selected through the "lang" query parameter and redirects GET requests to
+// the same URL without the parameter. It must be registered after NewMiddleware.
+func RememberLanguage() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			lang := c.QueryParam("lang")
+			if lang == "" {
+				return next(c)
+			}
+
+			appCfg, err := getConfig(c)
+			if err != nil {
+				return fmt.Errorf("i18n.RememberLanguage error: %v", err)
+			}
+			if _, ok := appCfg.localizerMap.Load(lang); !ok {
+				return next(c)
+			}
+			if err := appCfg.PersistLanguage(c, lang); err != nil {
+				return fmt.Errorf("i18n.RememberLanguage error: %v", err)
+			}
+
+			req := c.Request()
+			if req.Method != http.MethodGet && req.Method != http.MethodHead {
+				return next(c)
+			}
+			u := *req.URL
+			query := u.Query()
+			query.Del("lang")
+			u.RawQuery = query.Encode()
+			return c.Redirect(http.StatusFound, u.RequestURI())
+		}
+	}
+}
+
+// LanguageChangeHandler returns a handler, typically mounted at
+// POST /i18n/language, that validates the "lang" form value against
+// AcceptLanguages, persists it with PersistLanguage and redirects back to the
+// local "redirect" form value, the Referer, or "/".
+func LanguageChangeHandler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		appCfg, err := getConfig(c)
+		if err != nil {
+			return fmt.Errorf("i18n.LanguageChangeHandler error: %v", err)
+		}
+
+		tag, err := language.Parse(c.FormValue("lang"))
+		if err != nil || !appCfg.isAccepted(tag) {
+			return echo.NewHTTPError(http.StatusBadRequest, "unsupported language")
+		}
+		if err := appCfg.PersistLanguage(c, tag.String()); err != nil {
+			return fmt.Errorf("i18n.LanguageChangeHandler error: %v", err)
+		}
+		return c.Redirect(http.StatusSeeOther, redirectTarget(c))
+	}
+}
+
+// isAccepted reports whether the tag is one of the supported languages.
+func (c *Config) isAccepted(tag language.Tag) bool {
+	for _, lang := range c.AcceptLanguages {
+		if lang == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// redirectTarget returns the local path to redirect to after a language
+// change, ignoring targets on other hosts to avoid open redirects.
+func redirectTarget(c echo.Context) string {
+	if target := c.FormValue("redirect"); isLocalPath(target) {
+		return target
+	}
+	if referer, err := url.Parse(c.Request().Referer()); err == nil && referer.Host == c.Request().Host {
+		if target := referer.RequestURI(); isLocalPath(target) {
+			return target
+		}
+	}
+	return "/"
+}
+
+// isLocalPath reports whether target is a path on the current host.
+func isLocalPath(target string) bool {
+	return strings.HasPrefix(target, "/") && !strings.HasPrefix(target, "//") && !strings.HasPrefix(target, "/\\")
+}
@@ -0,0 +1,127 @@
+package echoi18n
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// TestRememberLanguage tests persisting and stripping the lang query parameter.
+func TestRememberLanguage(t *testing.T) {
+	t.Parallel()
+	app := echo.New()
+	app.Use(NewMiddleware(&Config{}), RememberLanguage())
+	app.GET("/", func(c echo.Context) error {
+		return c.String(http.StatusOK, MustLocalize(c, "welcome"))
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/?lang=zh&page=2", nil)
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusFound, rec.Code)
+	assert.Equal(t, "/?page=2", rec.Header().Get(echo.HeaderLocation))
+	cookies := rec.Result().Cookies()
+	assert.Len(t, cookies, 1)
+	assert.Equal(t, "lang", cookies[0].Name)
+	assert.Equal(t, "zh", cookies[0].Value)
+
+	req = httptest.NewRequest(http.MethodGet, "/?page=2", nil)
+	req.AddCookie(cookies[0])
+	rec = httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+	body, _ := io.ReadAll(rec.Body)
+	assert.Equal(t, "你好", string(body))
+
+	req = httptest.NewRequest(http.MethodGet, "/?lang=fr", nil)
+	rec = httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Result().Cookies())
+}
+
+// TestLanguageChangeHandler tests validating, persisting and redirecting back.
+func TestLanguageChangeHandler(t *testing.T) {
+	t.Parallel()
+	app := echo.New()
+	app.Use(NewMiddleware(&Config{}))
+	app.POST("/i18n/language", LanguageChangeHandler())
+
+	tests := []struct {
+		name     string
+		form     string
+		referer  string
+		code     int
+		location string
+		cookie   string
+	}{
+		{"redirect value", "lang=zh&redirect=/products", "", http.StatusSeeOther, "/products", "zh"},
+		{"referer", "lang=en", "http://example.com/about?x=1", http.StatusSeeOther, "/about?x=1", "en"},
+		{"foreign redirect", "lang=en&redirect=//evil.com", "http://evil.com/", http.StatusSeeOther, "/", "en"},
+		{"unsupported language", "lang=fr", "", http.StatusBadRequest, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/i18n/language", strings.NewReader(tt.form))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+			req.Header.Set("Referer", tt.referer)
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.code, rec.Code)
+			assert.Equal(t, tt.location, rec.Header().Get(echo.HeaderLocation))
+			var cookie string
+			if cookies := rec.Result().Cookies(); len(cookies) == 1 {
+				cookie = cookies[0].Value
+			}
+			assert.Equal(t, tt.cookie, cookie)
+		})
+	}
+}
+
+// TestSetLanguageCookie tests remembering a language in the configured cookie.
+func TestSetLanguageCookie(t *testing.T) {
+	t.Parallel()
+	app := echo.New()
+	app.Use(NewMiddleware(&Config{CookieName: "locale", CookiePath: "/app", CookieMaxAge: time.Hour}))
+	app.GET("/app/", func(c echo.Context) error {
+		if lang := c.QueryParam("choose"); lang != "" {
+			if err := SetLanguageCookie(c, language.Make(lang)); err != nil {
+				return c.String(http.StatusBadRequest, err.Error())
+			}
+		}
+		return c.String(http.StatusOK, MustLocalize(c, "welcome"))
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/app/?choose=zh", nil)
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+	cookies := rec.Result().Cookies()
+	assert.Len(t, cookies, 1)
+	assert.Equal(t, "locale", cookies[0].Name)
+	assert.Equal(t, "zh", cookies[0].Value)
+	assert.Equal(t, "/app", cookies[0].Path)
+	assert.Equal(t, 3600, cookies[0].MaxAge)
+
+	req = httptest.NewRequest(http.MethodGet, "/app/", nil)
+	req.AddCookie(cookies[0])
+	rec = httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+	body, _ := io.ReadAll(rec.Body)
+	assert.Equal(t, "你好", string(body))
+
+	req = httptest.NewRequest(http.MethodGet, "/app/?choose=fr", nil)
+	rec = httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Empty(t, rec.Result().Cookies())
+	body, _ = io.ReadAll(rec.Body)
+	assert.Equal(t, `i18n.SetLanguageCookie error: language "fr" is not supported`, string(body))
+}
@@ -0,0 +1,97 @@
+package echoi18n
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"golang.org/x/text/language"
+)
+
+// PhoneFormatter formats an E.164 phone number for a language, e.g. a
+// libphonenumber-backed implementation.
+type PhoneFormatter func(e164 string, lang language.Tag) (string, error)
+
+// phoneCountry describes the formats of a country calling code. Patterns use
+// '#' for digits of the national significant number.
+type phoneCountry struct {
+	regions       []string // Regions sharing the calling code.
+	trunkPrefix   string   // Prefix dialed before national numbers.
+	national      string   // National format pattern.
+	international string   // International format pattern after "+<code> ".
+}
+
+// phoneCountries are the built-in formats keyed by calling code.
+var phoneCountries = map[string]phoneCountry{
+	"1":  {regions: []string{"US", "CA"}, national: "(###) ###-####", international: "###-###-####"},
+	"33": {regions: []string{"FR"}, trunkPrefix: "0", national: "# ## ## ## ##", international: "# ## ## ## ##"},
+	"44": {regions: []string{"GB"}, trunkPrefix: "0", national: "#### ######", international: "#### ######"},
+	"49": {regions: []string{"DE"}, trunkPrefix: "0", national: "### ########", international: "### ########"},
+	"81": {regions: []string{"JP"}, trunkPrefix: "0", national: "##-####-####", international: "##-####-####"},
+	"86": {regions: []string{"CN"}, national: "### #### ####", international: "### #### ####"},
+}
+
+// applyPattern fills the '#' placeholders of pattern with digits, reporting
+// false if the number of digits does not match.
+func applyPattern(pattern, digits string) (string, bool) {
+	if strings.Count(pattern, "#") != len(digits) {
+		return "", false
+	}
+	var b strings.Builder
+	i := 0
+	for _, r := range pattern {
+		if r == '#' {
+			b.WriteByte(digits[i])
+			i++
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String(), true
+}
+
+// defaultFormatPhone formats numbers of the built-in countries in national
+// format for a matching region and in international format otherwise.
+func defaultFormatPhone(e164 string, lang language.Tag) (string, error) {
+	digits := strings.TrimPrefix(e164, "+")
+	if !strings.HasPrefix(e164, "+") || len(digits) < 4 || strings.Trim(digits, "0123456789") != "" {
+		return "", fmt.Errorf("invalid E.164 phone number %q", e164)
+	}
+
+	region, _ := lang.Region()
+	for i := 1; i <= 3; i++ {
+		country, ok := phoneCountries[digits[:i]]
+		if !ok {
+			continue
+		}
+		code, number := digits[:i], digits[i:]
+		for _, r := range country.regions {
+			if r == region.String() {
+				if national, ok := applyPattern(country.national, number); ok {
+					return country.trunkPrefix + national, nil
+				}
+			}
+		}
+		if international, ok := applyPattern(country.international, number); ok {
+			return "+" + code + " " + international, nil
+		}
+		return "+" + code + " " + number, nil
+	}
+	return e164, nil
+}
+
+// FormatPhone formats an E.164 phone number such as "+8613812345678" for the
+// negotiated language: national format when the number belongs to the
+// language's region, international format otherwise. The formatting backend
+// can be replaced with Config.PhoneFormatter.
+func FormatPhone(c echo.Context, e164 string) (string, error) {
+	appCfg, err := getConfig(c)
+	if err != nil {
+		return "", fmt.Errorf("i18n.FormatPhone error: %v", err)
+	}
+	phone, err := appCfg.PhoneFormatter(e164, language.Make(appCfg.language(c)))
+	if err != nil {
+		return "", fmt.Errorf("i18n.FormatPhone error: %v", err)
+	}
+	return phone, nil
+}
@@ -0,0 +1,65 @@
+package echoi18n
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// Test_defaultFormatPhone tests the built-in national and international formats.
+func Test_defaultFormatPhone(t *testing.T) {
+	tests := []struct {
+		e164 string
+		lang language.Tag
+		want string
+		err  string
+	}{
+		{"+14155550123", language.English, "(415) 555-0123", ""},
+		{"+14155550123", language.Chinese, "+1 415-555-0123", ""},
+		{"+8613812345678", language.Chinese, "138 1234 5678", ""},
+		{"+33612345678", language.French, "06 12 34 56 78", ""},
+		{"+33612345678", language.BritishEnglish, "+33 6 12 34 56 78", ""},
+		{"+861234", language.English, "+86 1234", ""},
+		{"+99912345", language.English, "+99912345", ""},
+		{"0612345678", language.French, "", `invalid E.164 phone number "0612345678"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.e164+" "+tt.lang.String(), func(t *testing.T) {
+			got, err := defaultFormatPhone(tt.e164, tt.lang)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// TestFormatPhone tests formatting for the negotiated language.
+func TestFormatPhone(t *testing.T) {
+	t.Parallel()
+	app := echo.New()
+	app.Use(NewMiddleware(&Config{}))
+	app.GET("/", func(c echo.Context) error {
+		phone, err := FormatPhone(c, "+8613812345678")
+		if err != nil {
+			return err
+		}
+		return c.String(http.StatusOK, phone)
+	})
+
+	got, err := makeRequest(language.Chinese, "", app)
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(got.Body)
+	assert.Equal(t, "138 1234 5678", string(body))
+
+	got, err = makeRequest(language.English, "", app)
+	assert.NoError(t, err)
+	body, _ = io.ReadAll(got.Body)
+	assert.Equal(t, "+86 138 1234 5678", string(body))
+}
@@ -0,0 +1,138 @@
+package echoi18n
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+	"text/template/parse"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// walkNodes calls fn for every node of a template parse tree.
+func walkNodes(node parse.Node, fn func(parse.Node)) {
+	if node == nil {
+		return
+	}
+	fn(node)
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			walkNodes(child, fn)
+		}
+	case *parse.ActionNode:
+		walkNodes(n.Pipe, fn)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			walkNodes(cmd, fn)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			walkNodes(arg, fn)
+		}
+	case *parse.ChainNode:
+		walkNodes(n.Node, fn)
+	case *parse.IfNode:
+		walkBranch(&n.BranchNode, fn)
+	case *parse.WithNode:
+		walkBranch(&n.BranchNode, fn)
+	case *parse.RangeNode:
+		walkBranch(&n.BranchNode, fn)
+	case *parse.TemplateNode:
+		walkNodes(n.Pipe, fn)
+	}
+}
+
+// walkBranch walks the pipeline and lists of a branch action.
+func walkBranch(n *parse.BranchNode, fn func(parse.Node)) {
+	walkNodes(n.Pipe, fn)
+	walkNodes(n.List, fn)
+	if n.ElseList != nil {
+		walkNodes(n.ElseList, fn)
+	}
+}
+
+// placeholders returns the sorted template data fields used by any plural
+// form of a message, e.g. "name" for "hello {{ .name }}".
+func (p *messageParser) placeholders(m *i18n.Message) ([]string, error) {
+	fields := map[string]bool{}
+	for _, src := range pluralForms(m) {
+		if p.isRaw(src, m.LeftDelim) {
+			continue
+		}
+		leftDelim, rightDelim := m.LeftDelim, m.RightDelim
+		if leftDelim == "" && p.leftDelim != "" && p.rightDelim != "" {
+			src = convertDelims(src, p.leftDelim, p.rightDelim, p.text.Funcs)
+		}
+		tmpl, err := texttemplate.New("").Delims(leftDelim, rightDelim).Funcs(p.text.Funcs).Parse(src)
+		if err != nil {
+			return nil, err
+		}
+		walkNodes(tmpl.Tree.Root, func(node parse.Node) {
+			if field, ok := node.(*parse.FieldNode); ok {
+				fields[strings.Join(field.Ident, ".")] = true
+			}
+		})
+	}
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// placeholderMismatches returns the sorted IDs of the translations in lang
+// whose placeholders differ from the default language message.
+func (c *Config) placeholderMismatches(lang string) []string {
+	translations := indexMessages(c.messages[lang])
+	var mismatched []string
+	for _, source := range c.messages[c.DefaultLanguage.String()] {
+		translation, ok := translations[source.ID]
+		if !ok {
+			continue
+		}
+		want, err := c.parser.placeholders(source)
+		if err != nil {
+			continue
+		}
+		got, err := c.parser.placeholders(translation)
+		if err != nil || strings.Join(got, ",") != strings.Join(want, ",") {
+			mismatched = append(mismatched, source.ID)
+		}
+	}
+	sort.Strings(mismatched)
+	return mismatched
+}
+
+// validatePlaceholders reports translations whose placeholders differ from
+// the default language message, as errors with PlaceholderCheck and as
+// warnings otherwise.
+func (c *Config) validatePlaceholders() Diagnostics {
+	severity := SeverityWarning
+	if c.PlaceholderCheck {
+		severity = SeverityError
+	}
+	var diagnostics Diagnostics
+	for _, lang := range c.AcceptLanguages {
+		if lang == c.DefaultLanguage {
+			continue
+		}
+		if mismatched := c.placeholderMismatches(lang.String()); len(mismatched) > 0 {
+			diagnostics = append(diagnostics, Diagnostic{
+				Severity: severity,
+				Lang:     lang.String(),
+				Message:  fmt.Sprintf("placeholders of %q in language %q differ from the default language", mismatched, lang),
+			})
+		}
+	}
+	return diagnostics
+}
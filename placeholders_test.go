@@ -0,0 +1,45 @@
+package echoi18n
+
+import (
+	"testing"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+)
+
+// placeholderBundle has a Chinese translation that dropped a placeholder.
+var placeholderBundle = Bundle{
+	"en": {
+		{ID: "greeting", Other: "hello {{ .name }}"},
+		{ID: "order", One: "{{ .count }} order for {{ .user.name }}", Other: "{{ .count }} orders for {{ .user.name }}"},
+	},
+	"zh": {
+		{ID: "greeting", Other: "你好"},
+		{ID: "order", Other: "{{ .user.name }} 的 {{ .count }} 个订单"},
+	},
+}
+
+// Test_messageParser_placeholders tests extracting template data fields.
+func Test_messageParser_placeholders(t *testing.T) {
+	p := (&Config{}).newMessageParser()
+	got, err := p.placeholders(&i18n.Message{Other: "{{ if .vip }}dear {{ end }}{{ .user.name }}"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"user.name", "vip"}, got)
+
+	p = (&Config{LeftDelim: "{", RightDelim: "}"}).newMessageParser()
+	got, err = p.placeholders(&i18n.Message{Other: "hello {name}"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"name"}, got)
+}
+
+// TestConfig_PlaceholderCheck tests reporting placeholder mismatches.
+func TestConfig_PlaceholderCheck(t *testing.T) {
+	t.Parallel()
+	cfg := &Config{Bundles: []Bundle{placeholderBundle}}
+	NewMiddleware(cfg)
+	assert.Equal(t, []string{"greeting"}, cfg.Stats().Languages["zh"].Mismatched)
+
+	assert.PanicsWithError(t, `i18n.NewMiddleware error: zh: placeholders of ["greeting"] in language "zh" differ from the default language`, func() {
+		NewMiddleware(&Config{Bundles: []Bundle{placeholderBundle}, PlaceholderCheck: true})
+	})
+}
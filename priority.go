@@ -0,0 +1,50 @@
+package echoi18n
+
+import "fmt"
+
+// loadCritical loads the CriticalShards namespaces, reporting unknown ones
+// and load failures as errors, so the middleware never serves a key page
+// without its messages. Their shards are pinned outside the MaxShards LRU.
+func (c *Config) loadCritical() Diagnostics {
+	var diagnostics Diagnostics
+	for _, namespace := range c.CriticalShards {
+		if !c.isNamespace(namespace) {
+			diagnostics = append(diagnostics, Diagnostic{Severity: SeverityError, Message: fmt.Sprintf("critical namespace %q is not in Namespaces", namespace)})
+			continue
+		}
+		if _, err := c.shard(namespace); err != nil {
+			diagnostics = append(diagnostics, Diagnostic{Severity: SeverityError, Message: fmt.Sprintf("critical namespace %q: %v", namespace, err), Err: err})
+		}
+	}
+	return diagnostics
+}
+
+// loadBackground loads the other namespaces while MaxShards allows it, so
+// their first use does not wait on the loader. A namespace failing to load
+// is logged and loaded again on first use.
+func (c *Config) loadBackground(done chan<- struct{}) {
+	defer close(done)
+	for _, namespace := range c.Namespaces {
+		if c.shards.full() {
+			return
+		}
+		if _, ok := c.shards.get(namespace); ok {
+			continue
+		}
+		if _, err := c.shard(namespace); err != nil {
+			c.logf("i18n: namespace %q: %v", namespace, err)
+		}
+	}
+}
+
+// BackgroundLoaded returns a channel closed once the namespaces loaded in the
+// background by the current catalog are in memory. It is closed at once
+// without BackgroundLoad, or before the middleware is created.
+func (c *Config) BackgroundLoaded() <-chan struct{} {
+	if background := c.active().background; background != nil {
+		return background
+	}
+	done := make(chan struct{})
+	close(done)
+	return done
+}
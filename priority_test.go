@@ -0,0 +1,85 @@
+package echoi18n
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newShardLoader creates a loader of the main catalog and a checkout namespace.
+func newShardLoader() Loader {
+	files := map[string]string{
+		"localize/en.yaml":          "welcome: hello",
+		"localize/zh.yaml":          "welcome: 你好",
+		"localize/checkout/en.yaml": "title: Checkout",
+		"localize/account/en.yaml":  "title: Account",
+	}
+	return LoaderFunc(func(path string) ([]byte, error) {
+		if content, ok := files[path]; ok {
+			return []byte(content), nil
+		}
+		return nil, os.ErrNotExist
+	})
+}
+
+// TestConfig_CriticalShards tests loading critical namespaces at startup.
+func TestConfig_CriticalShards(t *testing.T) {
+	t.Parallel()
+	cfg := &Config{
+		Loader:         newShardLoader(),
+		RootPath:       "localize",
+		Namespaces:     []string{"checkout", "account"},
+		CriticalShards: []string{"checkout"},
+	}
+	NewMiddleware(cfg)
+	<-cfg.BackgroundLoaded()
+	assert.Equal(t, []string{"checkout"}, cfg.LoadedNamespaces())
+
+	assert.PanicsWithError(t, `i18n.NewMiddleware error: critical namespace "search" is not in Namespaces`, func() {
+		NewMiddleware(&Config{
+			Loader:         newShardLoader(),
+			RootPath:       "localize",
+			Namespaces:     []string{"checkout"},
+			CriticalShards: []string{"search"},
+		})
+	})
+}
+
+// TestConfig_BackgroundLoad tests loading the other namespaces in the background.
+func TestConfig_BackgroundLoad(t *testing.T) {
+	t.Parallel()
+	cfg := &Config{
+		Loader:         newShardLoader(),
+		RootPath:       "localize",
+		Namespaces:     []string{"checkout", "account", "search"},
+		MaxShards:      2,
+		CriticalShards: []string{"checkout"},
+		BackgroundLoad: true,
+	}
+	NewMiddleware(cfg)
+	<-cfg.BackgroundLoaded()
+	assert.Equal(t, []string{"checkout", "search", "account"}, cfg.LoadedNamespaces())
+}
+
+// TestConfig_CriticalShards_pinned tests keeping the critical namespaces out
+// of the MaxShards LRU.
+func TestConfig_CriticalShards_pinned(t *testing.T) {
+	t.Parallel()
+	cfg := &Config{
+		Loader:         newShardLoader(),
+		RootPath:       "localize",
+		Namespaces:     []string{"checkout", "account", "search"},
+		MaxShards:      1,
+		CriticalShards: []string{"checkout"},
+	}
+	NewMiddleware(cfg)
+	assert.NoError(t, cfg.LoadNamespace("account"))
+	assert.NoError(t, cfg.LoadNamespace("search"))
+	assert.Equal(t, []string{"checkout", "search"}, cfg.LoadedNamespaces())
+
+	cfg.EvictNamespace("checkout")
+	assert.Equal(t, []string{"search"}, cfg.LoadedNamespaces())
+	assert.NoError(t, cfg.LoadNamespace("checkout"))
+	assert.Equal(t, []string{"checkout", "search"}, cfg.LoadedNamespaces())
+}
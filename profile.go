@@ -0,0 +1,24 @@
+package echoi18n
+
+import (
+	"context"
+	"runtime/pprof"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ProfileLabel is the pprof label carrying the negotiated language.
+const ProfileLabel = "lang"
+
+// serveLabeled runs the handler with the negotiated language as pprof label,
+// so CPU profiles can be broken down by locale. The labels are also set on
+// the request context for goroutines started by the handler.
+func (c *Config) serveLabeled(ctx echo.Context, next echo.HandlerFunc) error {
+	var err error
+	labels := pprof.Labels(ProfileLabel, c.language(ctx))
+	pprof.Do(ctx.Request().Context(), labels, func(labeled context.Context) {
+		ctx.SetRequest(ctx.Request().WithContext(labeled))
+		err = next(ctx)
+	})
+	return err
+}
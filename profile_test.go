@@ -0,0 +1,28 @@
+package echoi18n
+
+import (
+	"io"
+	"net/http"
+	"runtime/pprof"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// TestConfig_ProfileLabels tests labeling handlers with the negotiated language.
+func TestConfig_ProfileLabels(t *testing.T) {
+	t.Parallel()
+	app := echo.New()
+	app.Use(NewMiddleware(&Config{ProfileLabels: true}))
+	app.GET("/", func(c echo.Context) error {
+		lang, _ := pprof.Label(c.Request().Context(), ProfileLabel)
+		return c.String(http.StatusOK, lang)
+	})
+
+	got, err := makeRequest(language.Chinese, "", app)
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(got.Body)
+	assert.Equal(t, "zh", string(body))
+}
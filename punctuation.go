@@ -0,0 +1,93 @@
+package echoi18n
+
+import (
+	"github.com/labstack/echo/v4"
+	"golang.org/x/text/language"
+)
+
+// narrowNoBreakSpace separates French quotation marks and high punctuation
+// from the text they belong to.
+const narrowNoBreakSpace = "\u202f"
+
+// quotationMarks are the opening and closing quotation marks of each
+// language, keyed by tag or base language.
+var quotationMarks = map[string][2]string{
+	"en":      {"“", "”"},
+	"de":      {"„", "“"},
+	"cs":      {"„", "“"},
+	"pl":      {"„", "”"},
+	"fr":      {"«" + narrowNoBreakSpace, narrowNoBreakSpace + "»"},
+	"es":      {"«", "»"},
+	"it":      {"«", "»"},
+	"pt":      {"“", "”"},
+	"ru":      {"«", "»"},
+	"uk":      {"«", "»"},
+	"nl":      {"‘", "’"},
+	"sv":      {"”", "”"},
+	"fi":      {"”", "”"},
+	"ja":      {"「", "」"},
+	"zh":      {"“", "”"},
+	"zh-Hant": {"「", "」"},
+	"ko":      {"“", "”"},
+}
+
+// fullWidthPunctuation are the punctuation marks used by Chinese and Japanese.
+var fullWidthPunctuation = map[rune]string{
+	'!': "！",
+	'?': "？",
+	':': "：",
+	';': "；",
+	',': "，",
+}
+
+// requestLanguage returns the negotiated language, or und without middleware.
+func requestLanguage(c echo.Context) language.Tag {
+	if appCfg, err := getConfig(c); err == nil {
+		return language.Make(appCfg.language(c))
+	}
+	return language.Und
+}
+
+// Quote encloses s in the quotation marks of the negotiated language,
+// e.g. „s“ in German, « s » in French and 「s」 in Japanese.
+func Quote(c echo.Context, s string) string {
+	marks := quotationMarks["en"]
+	lang := requestLanguage(c)
+	base, _ := lang.Base()
+	script, _ := lang.Script()
+	if m, ok := quotationMarks[base.String()+"-"+script.String()]; ok {
+		marks = m
+	} else if m, ok := quotationMarks[base.String()]; ok {
+		marks = m
+	}
+	return marks[0] + s + marks[1]
+}
+
+// Punctuate ends the sentence s with the mark following the conventions of
+// the negotiated language: a narrow no-break space before ! ? : ; in French,
+// inverted opening marks in Spanish and full-width marks in Chinese and Japanese.
+func Punctuate(c echo.Context, s string, mark rune) string {
+	base, _ := requestLanguage(c).Base()
+	switch base.String() {
+	case "fr":
+		switch mark {
+		case '!', '?', ':', ';':
+			return s + narrowNoBreakSpace + string(mark)
+		}
+	case "es":
+		switch mark {
+		case '!':
+			return "¡" + s + "!"
+		case '?':
+			return "¿" + s + "?"
+		}
+	case "zh", "ja":
+		if full, ok := fullWidthPunctuation[mark]; ok {
+			return s + full
+		}
+		if mark == '.' {
+			return s + "。"
+		}
+	}
+	return s + string(mark)
+}
@@ -0,0 +1,55 @@
+package echoi18n
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// TestQuote tests quoting and punctuating in the negotiated language.
+func TestQuote(t *testing.T) {
+	t.Parallel()
+	app := echo.New()
+	app.Use(NewMiddleware(&Config{
+		AcceptLanguages: []language.Tag{
+			language.English, language.German, language.French, language.Spanish,
+			language.Japanese, language.SimplifiedChinese, language.TraditionalChinese,
+		},
+		Loader: LoaderFunc(func(path string) ([]byte, error) { return nil, nil }),
+	}))
+	app.GET("/", func(c echo.Context) error {
+		return c.String(http.StatusOK, Quote(c, "x")+" "+Punctuate(c, "y", '?'))
+	})
+
+	tests := []struct {
+		lang language.Tag
+		want string
+	}{
+		{language.English, "“x” y?"},
+		{language.German, "„x“ y?"},
+		{language.French, "«\u202fx\u202f» y\u202f?"},
+		{language.Spanish, "«x» ¿y?"},
+		{language.Japanese, "「x」 y？"},
+		{language.SimplifiedChinese, "“x” y？"},
+		{language.TraditionalChinese, "「x」 y？"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.lang.String(), func(t *testing.T) {
+			got, err := makeRequest(tt.lang, "", app)
+			assert.NoError(t, err)
+			body, _ := io.ReadAll(got.Body)
+			assert.Equal(t, tt.want, string(body))
+		})
+	}
+}
+
+// TestPunctuate_withoutMiddleware tests the fallback without the middleware.
+func TestPunctuate_withoutMiddleware(t *testing.T) {
+	c := echo.New().NewContext(nil, nil)
+	assert.Equal(t, "Done.", Punctuate(c, "Done", '.'))
+	assert.Equal(t, "“x”", Quote(c, "x"))
+}
@@ -0,0 +1,55 @@
+package echoi18n
+
+import (
+	"strings"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// rawTemplate is a message served verbatim without template execution.
+type rawTemplate string
+
+// Execute returns the message text.
+func (t rawTemplate) Execute(interface{}) (string, error) {
+	return string(t), nil
+}
+
+// isRaw reports whether a message template can be served verbatim, either
+// because RawStrings is set or because it contains no placeholders.
+func (p *messageParser) isRaw(src, leftDelim string) bool {
+	if p.rawStrings {
+		return true
+	}
+	if leftDelim == "" {
+		leftDelim = p.leftDelim
+	}
+	if leftDelim == "" {
+		leftDelim = "{{"
+	}
+	return !strings.Contains(src, leftDelim)
+}
+
+// initRawMessages indexes the messages that have a single form and can be
+// served verbatim, so Localize returns them without going through go-i18n.
+func (c *Config) initRawMessages() {
+	raw := make(map[string]map[string]string, len(c.messages))
+	for lang, messages := range c.messages {
+		raw[lang] = map[string]string{}
+		for _, m := range messages {
+			if len(pluralForms(m)) == 1 && m.Other != "" && c.parser.isRaw(m.Other, m.LeftDelim) {
+				raw[lang][m.ID] = m.Other
+			}
+		}
+	}
+	c.raw = raw
+}
+
+// rawMessage returns the verbatim text of a message if the localize config
+// needs no template execution or plural selection.
+func (c *Config) rawMessage(lang string, lc *i18n.LocalizeConfig) (string, bool) {
+	if lc.PluralCount != nil || lc.DefaultMessage != nil || lc.TemplateParser != nil || lc.Funcs != nil {
+		return "", false
+	}
+	text, ok := c.raw[lang][lc.MessageID]
+	return text, ok
+}
@@ -0,0 +1,40 @@
+package echoi18n
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// TestConfig_initRawMessages tests detecting messages without placeholders.
+func TestConfig_initRawMessages(t *testing.T) {
+	t.Parallel()
+	cfg := &Config{Bundles: []Bundle{{"en": {{ID: "items", One: "one item", Other: "many items"}}}}}
+	NewMiddleware(cfg)
+
+	assert.Equal(t, map[string]string{"welcome": "hello"}, cfg.active().raw["en"])
+	assert.Equal(t, map[string]string{"welcome": "你好"}, cfg.active().raw["zh"])
+}
+
+// TestConfig_RawStrings tests serving all messages verbatim.
+func TestConfig_RawStrings(t *testing.T) {
+	t.Parallel()
+	app := echo.New()
+	app.Use(NewMiddleware(&Config{RawStrings: true}))
+	app.GET("/:name", func(c echo.Context) error {
+		return c.String(http.StatusOK, MustLocalize(c, &i18n.LocalizeConfig{
+			MessageID:    "welcomeWithName",
+			TemplateData: map[string]string{"name": c.Param("name")},
+		}))
+	})
+
+	got, err := makeRequest(language.English, "alex", app)
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(got.Body)
+	assert.Equal(t, "hello {{ .name }}", string(body))
+}
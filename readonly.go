@@ -0,0 +1,15 @@
+package echoi18n
+
+import "errors"
+
+// ErrReadOnly is returned by the runtime catalog changes of a ReadOnly Config.
+var ErrReadOnly = errors.New("catalog is read-only")
+
+// writable returns ErrReadOnly if the middleware rejects runtime catalog
+// changes.
+func (c *Config) writable() error {
+	if c.active().ReadOnly {
+		return ErrReadOnly
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package echoi18n
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestConfig_ReadOnly tests rejecting runtime catalog changes.
+func TestConfig_ReadOnly(t *testing.T) {
+	t.Parallel()
+	cfg := &Config{RootPath: "testdata/workflow", ReadOnly: true}
+	app := newAdminServer(cfg)
+
+	err := cfg.SetState("zh", "welcome", StateReviewed)
+	assert.True(t, errors.Is(err, ErrReadOnly))
+	assert.Equal(t, StateApproved, cfg.State("zh", "welcome"))
+
+	_, err = cfg.Schedule(time.Now())
+	assert.True(t, errors.Is(err, ErrReadOnly))
+	assert.True(t, errors.Is(cfg.CancelSchedule(), ErrReadOnly))
+
+	req := httptest.NewRequest(http.MethodPut, "/admin/state/zh/welcome", strings.NewReader(`{"state": "reviewed"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	assert.NoError(t, cfg.Reload())
+}
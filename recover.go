@@ -0,0 +1,41 @@
+package echoi18n
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// RecoverHandler returns the text served by MustLocalize in place of a
+// message that failed to localize.
+type RecoverHandler func(c echo.Context, params interface{}, err error) string
+
+// paramsMessageID returns the message ID of Localize params.
+func paramsMessageID(params interface{}) string {
+	switch p := params.(type) {
+	case string:
+		return p
+	case *i18n.LocalizeConfig:
+		if p.MessageID == "" && p.DefaultMessage != nil {
+			return p.DefaultMessage.ID
+		}
+		return p.MessageID
+	case MessageRef:
+		return p.ID
+	case *MessageRef:
+		return p.ID
+	}
+	return ""
+}
+
+// RecoverMessageID is a RecoverHandler logging the error and serving the
+// message ID, so a missing translation degrades the page instead of failing it.
+// The error is logged with the Config Logger, or the Logger of the Echo
+// instance when nil.
+func RecoverMessageID(c echo.Context, params interface{}, err error) string {
+	if appCfg, cfgErr := getConfig(c); cfgErr == nil && appCfg.Logger != nil {
+		appCfg.logf("i18n: %v", err)
+	} else {
+		c.Logger().Errorf("i18n: %v", err)
+	}
+	return paramsMessageID(params)
+}
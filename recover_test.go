@@ -0,0 +1,61 @@
+package echoi18n
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// TestConfig_RecoverHandler tests recovering from MustLocalize failures.
+func TestConfig_RecoverHandler(t *testing.T) {
+	t.Parallel()
+	var logs bytes.Buffer
+	app := echo.New()
+	app.Logger.SetOutput(&logs)
+	app.Use(NewMiddleware(&Config{RecoverHandler: RecoverMessageID}))
+	app.GET("/", func(c echo.Context) error {
+		return c.String(http.StatusOK, MustLocalize(c, "missing"))
+	})
+	app.GET("/fallback", func(c echo.Context) error {
+		return c.String(http.StatusOK, MustLocalize(c, &i18n.LocalizeConfig{MessageID: "missing"}))
+	})
+	custom := echo.New()
+	custom.Use(NewMiddleware(&Config{RecoverHandler: func(c echo.Context, params interface{}, err error) string {
+		return "…"
+	}}))
+	custom.GET("/", func(c echo.Context) error {
+		return c.String(http.StatusOK, MustLocalize(c, "missing"))
+	})
+
+	tests := []struct {
+		name string
+		app  *echo.Echo
+		url  string
+		want string
+	}{
+		{"message id", app, "", "missing"},
+		{"localize config", app, "fallback", "missing"},
+		{"custom", custom, "", "…"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := makeRequest(language.Chinese, tt.url, tt.app)
+			assert.NoError(t, err)
+			body, _ := io.ReadAll(got.Body)
+			assert.Equal(t, tt.want, string(body))
+		})
+	}
+
+	assert.Equal(t, 2, strings.Count(logs.String(), "i18n: i18n.Localize error: message"))
+
+	c := echo.New().NewContext(nil, nil)
+	c.Set(localsKey, &resolution{catalog: configDefault(&Config{})})
+	assert.Panics(t, func() { MustLocalize(c, 42) })
+}
@@ -0,0 +1,55 @@
+package echoi18n
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// botUserAgents are User-Agent fragments of common bots and crawlers.
+var botUserAgents = []string{"bot", "crawl", "spider", "slurp", "facebookexternalhit", "mediapartners"}
+
+// defaultIsBot reports whether the User-Agent looks like a bot or crawler.
+func defaultIsBot(r *http.Request) bool {
+	ua := strings.ToLower(r.UserAgent())
+	for _, fragment := range botUserAgents {
+		if strings.Contains(ua, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// RootRedirectHandler returns a handler for "/" that redirects with 302 to the
+// language-prefixed home page of the negotiated language. Bots and crawlers are
+// served by botHandler without redirect, so they index the default language;
+// if botHandler is nil they are redirected to the default language instead.
+// With MultipleChoices set, requests accepting several languages equally are
+// answered with 300 and the list of language variants.
+func RootRedirectHandler(botHandler echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		appCfg, err := getConfig(c)
+		if err != nil {
+			return fmt.Errorf("i18n.RootRedirectHandler error: %v", err)
+		}
+
+		lang := appCfg.DefaultLanguage.String()
+		if appCfg.IsBot(c.Request()) {
+			if botHandler != nil {
+				return botHandler(c)
+			}
+		} else {
+			if appCfg.MultipleChoices {
+				if langs := appCfg.equalMatches(c.Request()); len(langs) > 1 {
+					return appCfg.multipleChoices(c, "/", langs)
+				}
+			}
+			lang = appCfg.language(c)
+		}
+
+		addVary(c.Response().Header(), "Accept-Language")
+		return c.Redirect(http.StatusFound, appCfg.LocalizedPath(lang, "/"))
+	}
+}
@@ -0,0 +1,50 @@
+package echoi18n
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRootRedirectHandler tests redirecting visitors and serving bots.
+func TestRootRedirectHandler(t *testing.T) {
+	t.Parallel()
+	app := echo.New()
+	app.Use(NewMiddleware(&Config{}))
+	app.GET("/", RootRedirectHandler(func(c echo.Context) error {
+		return c.String(http.StatusOK, MustLocalize(c, "welcome"))
+	}))
+
+	tests := []struct {
+		name      string
+		userAgent string
+		lang      string
+		code      int
+		location  string
+		body      string
+	}{
+		{"visitor", "Mozilla/5.0", "zh", http.StatusFound, "/zh/", ""},
+		{"unsupported language", "Mozilla/5.0", "fr", http.StatusFound, "/en/", ""},
+		{"bot", "Mozilla/5.0 (compatible; Googlebot/2.1)", "", http.StatusOK, "", "hello"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Accept-Language", tt.lang)
+			req.Header.Set("User-Agent", tt.userAgent)
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			got := rec.Result()
+			assert.Equal(t, tt.code, got.StatusCode)
+			assert.Equal(t, tt.location, got.Header.Get(echo.HeaderLocation))
+			body, _ := io.ReadAll(got.Body)
+			assert.Equal(t, tt.body, string(body))
+		})
+	}
+}
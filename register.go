@@ -0,0 +1,34 @@
+package echoi18n
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+// Registers of address, loaded from the <lang>-x-formal and <lang>-x-informal
+// variant catalogs, e.g. "Sie" and "du" in German.
+const (
+	RegisterFormal   = "formal"
+	RegisterInformal = "informal"
+)
+
+// catalogVariants returns the variants whose catalogs are loaded for each
+// language: the register catalogs when a RegisterResolver is set, then Variants.
+func (c *Config) catalogVariants() []string {
+	if c.RegisterResolver == nil {
+		return c.Variants
+	}
+	return append([]string{RegisterFormal, RegisterInformal}, c.Variants...)
+}
+
+// register returns the register of the request, empty for the neutral form.
+func (c *Config) register(ctx echo.Context) string {
+	if c.RegisterResolver == nil {
+		return ""
+	}
+	switch register := c.RegisterResolver(ctx); register {
+	case RegisterFormal, RegisterInformal:
+		return register
+	default:
+		return ""
+	}
+}
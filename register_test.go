@@ -0,0 +1,42 @@
+package echoi18n
+
+import (
+	"io"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// TestLocalize_register tests selecting the register per request.
+func TestLocalize_register(t *testing.T) {
+	t.Parallel()
+	app := newVariantServer(&Config{
+		RegisterResolver: func(c echo.Context) string {
+			return c.QueryParam("register")
+		},
+	})
+
+	tests := []struct {
+		name string
+		lang language.Tag
+		url  string
+		want string
+	}{
+		{"formal", language.German, "welcome?register=formal", "Willkommen, schön, dass Sie da sind"},
+		{"informal", language.German, "welcome?register=informal", "Willkommen, schön, dass du da bist"},
+		{"neutral", language.German, "welcome", "Willkommen"},
+		{"unknown register", language.German, "welcome?register=casual", "Willkommen"},
+		{"neutral fallback", language.German, "help?register=formal", "Hilfe"},
+		{"language without registers", language.English, "welcome?register=formal", "Welcome"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := makeRequest(tt.lang, tt.url, app)
+			assert.NoError(t, err)
+			body, _ := io.ReadAll(got.Body)
+			assert.Equal(t, tt.want, string(body))
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package echoi18n
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/labstack/echo/v4"
+)
+
+// middlewareRegistry records the Configs served by i18n middlewares in the
+// process, to detect conflicting registrations at startup. Only the
+// Exclusive Config is held: the others are counted, so Configs never closed
+// are not kept in memory by the registry.
+type middlewareRegistry struct {
+	mu        sync.Mutex
+	count     int     // Registered Configs.
+	exclusive *Config // Registered Exclusive Config, nil if none.
+}
+
+// registry is the middleware registry of the process.
+var registry = &middlewareRegistry{}
+
+// register records a middleware created for cfg. It fails if cfg or another
+// registered Config is Exclusive, and warns if cfg was already registered.
+func (r *middlewareRegistry) register(cfg *Config) (Diagnostics, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	others := r.count
+	if cfg.registrations > 0 {
+		others--
+	}
+	if others > 0 && (cfg.Exclusive || r.exclusive != nil) {
+		return nil, fmt.Errorf("i18n.NewMiddleware error: %d other i18n middleware Configs are registered alongside an Exclusive one", others)
+	}
+	if cfg.registrations++; cfg.registrations == 1 {
+		r.count++
+	}
+	if cfg.Exclusive {
+		r.exclusive = cfg
+	}
+	if cfg.registrations == 1 {
+		return nil, nil
+	}
+	return Diagnostics{{
+		Severity: SeverityWarning,
+		Message:  fmt.Sprintf("NewMiddleware called %d times with the same Config, the last catalog load serves all of them", cfg.registrations),
+	}}, nil
+}
+
+// checkNested reports once when another i18n middleware already served the
+// request, its Config being replaced by the one of this middleware.
+func (c *Config) checkNested(ctx echo.Context, once *sync.Once) {
+	outer, ok := ctx.Get(localsKey).(*resolution)
+	if !ok || outer.catalog.root() == c {
+		return
+	}
+	once.Do(func() {
+		ctx.Logger().Errorf("i18n: middleware of another Config already served %s, its Config is replaced; use NewGroupMiddleware to override the negotiation of a group", ctx.Path())
+	})
+}
+
+// unregister removes a middleware registered for cfg whose creation failed.
+func (r *middlewareRegistry) unregister(cfg *Config) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if cfg.registrations--; cfg.registrations > 0 {
+		return
+	}
+	r.remove(cfg)
+}
+
+// release removes all the middlewares registered for cfg, once it is closed.
+func (r *middlewareRegistry) release(cfg *Config) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if cfg.registrations > 0 {
+		cfg.registrations = 0
+		r.remove(cfg)
+	}
+}
+
+// remove deletes cfg from the registry. r.mu must be held.
+func (r *middlewareRegistry) remove(cfg *Config) {
+	r.count--
+	if r.exclusive == cfg {
+		r.exclusive = nil
+	}
+}
@@ -0,0 +1,93 @@
+package echoi18n
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// Test_middlewareRegistry tests detecting conflicting middleware registrations.
+func Test_middlewareRegistry(t *testing.T) {
+	t.Parallel()
+	r := &middlewareRegistry{}
+	shared, other := &Config{}, &Config{}
+
+	diagnostics, err := r.register(shared)
+	assert.NoError(t, err)
+	assert.Empty(t, diagnostics)
+	diagnostics, err = r.register(shared)
+	assert.NoError(t, err)
+	assert.Equal(t, Diagnostics{{
+		Severity: SeverityWarning,
+		Message:  "NewMiddleware called 2 times with the same Config, the last catalog load serves all of them",
+	}}, diagnostics)
+
+	_, err = r.register(&Config{Exclusive: true})
+	assert.EqualError(t, err, "i18n.NewMiddleware error: 1 other i18n middleware Configs are registered alongside an Exclusive one")
+
+	exclusive, exclusiveCfg := &middlewareRegistry{}, &Config{Exclusive: true}
+	_, err = exclusive.register(exclusiveCfg)
+	assert.NoError(t, err)
+	_, err = exclusive.register(other)
+	assert.EqualError(t, err, "i18n.NewMiddleware error: 1 other i18n middleware Configs are registered alongside an Exclusive one")
+
+	exclusive.release(other)
+	exclusive.release(exclusiveCfg)
+	assert.Equal(t, &middlewareRegistry{}, exclusive)
+	_, err = exclusive.register(other)
+	assert.NoError(t, err)
+
+	r.release(shared)
+	assert.Equal(t, 0, shared.registrations)
+	assert.Equal(t, &middlewareRegistry{}, r)
+}
+
+// TestConfig_Close_registry tests releasing a closed Config from the registry.
+func TestConfig_Close_registry(t *testing.T) {
+	t.Parallel()
+	cfg := &Config{}
+	NewMiddleware(cfg)
+	NewMiddleware(cfg)
+	registry.mu.Lock()
+	assert.Equal(t, 2, cfg.registrations)
+	registry.mu.Unlock()
+
+	assert.NoError(t, cfg.Close())
+	registry.mu.Lock()
+	assert.Equal(t, 0, cfg.registrations)
+	registry.mu.Unlock()
+}
+
+// TestNewMiddleware_nested tests warning once about nested middlewares of different Configs.
+func TestNewMiddleware_nested(t *testing.T) {
+	t.Parallel()
+	var logs bytes.Buffer
+	app := echo.New()
+	app.Logger.SetOutput(&logs)
+	app.Use(NewMiddleware(&Config{}))
+	app.GET("/", func(c echo.Context) error {
+		return c.String(http.StatusOK, MustLocalize(c, "welcome"))
+	}, NewMiddleware(&Config{DefaultLanguage: language.Chinese}))
+
+	for i := 0; i < 2; i++ {
+		got, err := makeRequest(language.Und, "", app)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, got.StatusCode)
+	}
+	assert.Equal(t, 1, strings.Count(logs.String(), "i18n: middleware of another Config already served /"))
+}
+
+// Test_getConfig tests the error of a context key set by another middleware.
+func Test_getConfig(t *testing.T) {
+	t.Parallel()
+	c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+	c.Set(localsKey, "other")
+	_, err := getConfig(c)
+	assert.EqualError(t, err, `Config is not set by the i18n middleware: the "echoi18n" context key holds a string set by another middleware`)
+}
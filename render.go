@@ -0,0 +1,225 @@
+package echoi18n
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// noCacheKey is the Echo Context key set when a non-cacheable message was rendered.
+const noCacheKey = "echoi18n.noCache"
+
+// Cache is a read-through cache of rendered template messages. Plug a Redis
+// or memcached client to share the rendered messages across instances; keys
+// include the catalog version, so a new catalog never reads stale entries,
+// and a hash of the request data, which is never sent to the cache as is.
+// Failures of a shared cache should be reported as misses.
+type Cache interface {
+	// Get returns the cached message of key, or false if it is missing or expired.
+	Get(key string) (string, bool)
+	// Set caches the message of key for ttl, without expiry when 0.
+	Set(key, message string, ttl time.Duration)
+}
+
+// renderEntry is a rendered message of the render cache.
+type renderEntry struct {
+	key     string
+	message string
+	expires time.Time // Expiry of the entry, zero without expiry.
+}
+
+// renderCache is the in-process LRU Cache of rendered template messages.
+type renderCache struct {
+	mu      sync.Mutex
+	max     int                      // Maximum number of entries.
+	now     func() time.Time         // Current time source of the expiries.
+	order   *list.List               // Entries, most recently used first.
+	entries map[string]*list.Element // Elements of order keyed by render key.
+}
+
+// newRenderCache creates a render cache of at most max entries.
+func newRenderCache(max int, now func() time.Time) *renderCache {
+	return &renderCache{max: max, now: now, order: list.New(), entries: map[string]*list.Element{}}
+}
+
+// Get returns a cached rendered message and marks it as recently used.
+func (r *renderCache) Get(key string) (string, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	elem, ok := r.entries[key]
+	if !ok {
+		return "", false
+	}
+	entry := elem.Value.(*renderEntry)
+	if !entry.expires.IsZero() && !r.now().Before(entry.expires) {
+		r.order.Remove(elem)
+		delete(r.entries, key)
+		return "", false
+	}
+	r.order.MoveToFront(elem)
+	return entry.message, true
+}
+
+// Set caches a rendered message, evicting the least recently used ones.
+func (r *renderCache) Set(key, message string, ttl time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var expires time.Time
+	if ttl > 0 {
+		expires = r.now().Add(ttl)
+	}
+	if elem, ok := r.entries[key]; ok {
+		entry := elem.Value.(*renderEntry)
+		entry.message, entry.expires = message, expires
+		r.order.MoveToFront(elem)
+		return
+	}
+	r.entries[key] = r.order.PushFront(&renderEntry{key: key, message: message, expires: expires})
+	for r.order.Len() > r.max {
+		oldest := r.order.Back()
+		r.order.Remove(oldest)
+		delete(r.entries, oldest.Value.(*renderEntry).key)
+	}
+}
+
+// maxCanonicalDepth bounds the nesting of the template data encoded in
+// render cache keys, which also stops on cyclic data.
+const maxCanonicalDepth = 32
+
+// renderKey returns the render cache key of a message in the catalog
+// version, or false if the localize config cannot be cached. The request
+// data is hashed, so shared caches never see it, e.g. user names or emails.
+func (c *Config) renderKey(lang string, lc *i18n.LocalizeConfig) (string, bool) {
+	if lc.DefaultMessage != nil || lc.TemplateParser != nil || lc.Funcs != nil {
+		return "", false
+	}
+	var buf bytes.Buffer
+	writeCanonical(&buf, reflect.ValueOf(lang), 0)
+	writeCanonical(&buf, reflect.ValueOf(lc.MessageID), 0)
+	if !writeCanonical(&buf, reflect.ValueOf(lc.PluralCount), 0) || !writeCanonical(&buf, reflect.ValueOf(lc.TemplateData), 0) {
+		return "", false
+	}
+	sum := sha256.Sum256(buf.Bytes())
+	return c.version + ":" + hex.EncodeToString(sum[:]), true
+}
+
+// writeCanonical writes an unambiguous encoding of a value: its type then its
+// content, following pointers and sorting map entries, so equal data always
+// has the same encoding and different data never does. Values holding
+// functions or channels, or nested deeper than maxCanonicalDepth, cannot be
+// encoded.
+func writeCanonical(buf *bytes.Buffer, v reflect.Value, depth int) bool {
+	if depth > maxCanonicalDepth {
+		return false
+	}
+	if !v.IsValid() {
+		buf.WriteString("nil;")
+		return true
+	}
+	buf.WriteString(strconv.Quote(v.Type().String()))
+	buf.WriteByte(':')
+	switch v.Kind() {
+	case reflect.Bool:
+		buf.WriteString(strconv.FormatBool(v.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		buf.WriteString(strconv.FormatInt(v.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		buf.WriteString(strconv.FormatUint(v.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		buf.WriteString(strconv.FormatFloat(v.Float(), 'g', -1, 64))
+	case reflect.Complex64, reflect.Complex128:
+		buf.WriteString(strconv.FormatComplex(v.Complex(), 'g', -1, 128))
+	case reflect.String:
+		buf.WriteString(strconv.Quote(v.String()))
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			buf.WriteString("nil")
+		} else if !writeCanonical(buf, v.Elem(), depth+1) {
+			return false
+		}
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			buf.WriteString("nil")
+			break
+		}
+		buf.WriteString("[" + strconv.Itoa(v.Len()))
+		for i := 0; i < v.Len(); i++ {
+			if !writeCanonical(buf, v.Index(i), depth+1) {
+				return false
+			}
+		}
+		buf.WriteByte(']')
+	case reflect.Map:
+		if v.IsNil() {
+			buf.WriteString("nil")
+			break
+		}
+		entries := make([]string, 0, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			var entry bytes.Buffer
+			if !writeCanonical(&entry, iter.Key(), depth+1) || !writeCanonical(&entry, iter.Value(), depth+1) {
+				return false
+			}
+			entries = append(entries, entry.String())
+		}
+		sort.Strings(entries)
+		buf.WriteString("{" + strconv.Itoa(len(entries)))
+		for _, entry := range entries {
+			buf.WriteString(entry)
+		}
+		buf.WriteByte('}')
+	case reflect.Struct:
+		buf.WriteByte('{')
+		for i := 0; i < v.NumField(); i++ {
+			buf.WriteString(strconv.Quote(v.Type().Field(i).Name))
+			if !writeCanonical(buf, v.Field(i), depth+1) {
+				return false
+			}
+		}
+		buf.WriteByte('}')
+	default:
+		return false
+	}
+	buf.WriteByte(';')
+	return true
+}
+
+// isCacheable reports whether a message may be cached. Messages are marked
+// non-cacheable with a cache: "false" field, e.g. when they embed the date.
+func (c *Config) isCacheable(lang, id string) bool {
+	return c.metadata[lang][baseID(id)]["cache"] != "false"
+}
+
+// initUncacheable records whether messages are marked non-cacheable, so the
+// middleware only checks the responses of catalogs that have some.
+func (c *Config) initUncacheable() {
+	c.uncacheable = false
+	for _, metadata := range c.metadata {
+		for _, fields := range metadata {
+			if fields["cache"] == "false" {
+				c.uncacheable = true
+				return
+			}
+		}
+	}
+}
+
+// Cacheable reports whether the response may be cached, that is whether no
+// message marked non-cacheable was localized for the request so far. The
+// middleware sends the responses that are not with "Cache-Control: private,
+// no-store" and without the CacheKeyHeader and Surrogate-Key headers; the
+// messages localized after the response is written are not accounted for.
+func Cacheable(c echo.Context) bool {
+	noCache, _ := c.Get(noCacheKey).(bool)
+	return !noCache
+}
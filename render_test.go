@@ -0,0 +1,206 @@
+package echoi18n
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// renderLoader loads the message files of the render cache tests.
+var renderLoader = LoaderFunc(func(path string) ([]byte, error) {
+	if path != "localize/en.yaml" {
+		return nil, os.ErrNotExist
+	}
+	return []byte(`
+hello: Hello {{ .name }}
+today:
+  other: Today is {{ .date }}
+  cache: "false"
+notice:
+  other: Maintenance tonight
+  cache: "false"
+`), nil
+})
+
+// newRenderContext creates an Echo Context served by a render cache middleware.
+func newRenderContext(cfg *Config) echo.Context {
+	cfg.AcceptLanguages = []language.Tag{language.English}
+	cfg.Loader = renderLoader
+	cfg.RootPath = "localize"
+	app := echo.New()
+	c := app.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+	_ = NewMiddleware(cfg)(func(echo.Context) error { return nil })(c)
+	return c
+}
+
+// TestLocalize_renderCache tests caching rendered messages except non-cacheable ones.
+func TestLocalize_renderCache(t *testing.T) {
+	t.Parallel()
+	cfg := &Config{RenderCache: 2}
+	c := newRenderContext(cfg)
+
+	for i := 0; i < 3; i++ {
+		got, err := Localize(c, &i18n.LocalizeConfig{MessageID: "hello", TemplateData: map[string]string{"name": "Ann"}})
+		assert.NoError(t, err)
+		assert.Equal(t, "Hello Ann", got)
+	}
+	assert.Equal(t, 1, cfg.active().renders.(*renderCache).order.Len())
+	assert.True(t, Cacheable(c))
+
+	for i := 0; i < 3; i++ {
+		got, err := Localize(c, &i18n.LocalizeConfig{MessageID: "hello", TemplateData: map[string]string{"name": strconv.Itoa(i)}})
+		assert.NoError(t, err)
+		assert.Equal(t, "Hello "+strconv.Itoa(i), got)
+	}
+	assert.Equal(t, 2, cfg.active().renders.(*renderCache).order.Len())
+
+	got, err := Localize(c, &i18n.LocalizeConfig{MessageID: "today", TemplateData: map[string]string{"date": "Monday"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "Today is Monday", got)
+	key, _ := cfg.active().renderKey("en", &i18n.LocalizeConfig{MessageID: "today", TemplateData: map[string]string{"date": "Monday"}})
+	_, ok := cfg.active().renders.Get(key)
+	assert.False(t, ok)
+	assert.False(t, Cacheable(c))
+}
+
+// TestCacheable tests that non-cacheable messages are reported without a render cache.
+func TestCacheable(t *testing.T) {
+	t.Parallel()
+	c := newRenderContext(&Config{})
+	assert.Equal(t, "Hello Bob", MustLocalize(c, &i18n.LocalizeConfig{MessageID: "hello", TemplateData: map[string]string{"name": "Bob"}}))
+	assert.True(t, Cacheable(c))
+	MustLocalize(c, "today")
+	assert.False(t, Cacheable(c))
+}
+
+// TestCacheable_headers tests sending the responses with non-cacheable
+// messages as private and without cache keys.
+func TestCacheable_headers(t *testing.T) {
+	t.Parallel()
+	app := echo.New()
+	app.Use(NewMiddleware(&Config{
+		AcceptLanguages: []language.Tag{language.English},
+		Loader:          renderLoader,
+		RootPath:        "localize",
+		NoLangHeaders:   true,
+		CacheKeyHeader:  HeaderCacheKey,
+	}))
+	app.GET("/:id", func(c echo.Context) error {
+		return c.String(http.StatusOK, MustLocalize(c, &i18n.LocalizeConfig{MessageID: c.Param("id"), TemplateData: map[string]string{"name": "Ann", "date": "Monday"}}))
+	})
+
+	tests := []struct {
+		name             string
+		id               string
+		wantCacheControl string
+		wantCacheKey     string
+	}{
+		{"cacheable", "hello", "", "en"},
+		{"template", "today", "private, no-store", ""},
+		{"verbatim", "notice", "private, no-store", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := makeRequest(language.English, tt.id, app)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantCacheControl, got.Header.Get(echo.HeaderCacheControl))
+			assert.Equal(t, tt.wantCacheKey, got.Header.Get(HeaderCacheKey))
+		})
+	}
+}
+
+// mapCache is a Cache recording the TTL of the cached messages.
+type mapCache struct {
+	mu       sync.Mutex
+	messages map[string]string
+	ttls     map[string]time.Duration
+}
+
+// Get returns a cached message.
+func (m *mapCache) Get(key string) (string, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	message, ok := m.messages[key]
+	return message, ok
+}
+
+// Set caches a message.
+func (m *mapCache) Set(key, message string, ttl time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.messages[key], m.ttls[key] = message, ttl
+}
+
+// TestConfig_Cache tests reading and filling a shared Cache.
+func TestConfig_Cache(t *testing.T) {
+	t.Parallel()
+	cache := &mapCache{messages: map[string]string{}, ttls: map[string]time.Duration{}}
+	cfg := &Config{Cache: cache, CacheTTL: time.Minute}
+	c := newRenderContext(cfg)
+
+	ann := &i18n.LocalizeConfig{MessageID: "hello", TemplateData: map[string]string{"name": "Ann"}}
+	assert.Equal(t, "Hello Ann", MustLocalize(c, ann))
+	key, ok := cfg.active().renderKey("en", ann)
+	assert.True(t, ok)
+	assert.Equal(t, "Hello Ann", cache.messages[key])
+	assert.Equal(t, time.Minute, cache.ttls[key])
+	assert.Contains(t, key, cfg.Version())
+	assert.NotContains(t, key, "Ann")
+
+	cache.Set(key, "Hi Ann", 0)
+	assert.Equal(t, "Hi Ann", MustLocalize(c, ann))
+}
+
+// TestConfig_renderKey tests keying rendered messages on their template data.
+func TestConfig_renderKey(t *testing.T) {
+	t.Parallel()
+	cfg := &Config{}
+	key := func(data interface{}) string {
+		key, ok := cfg.renderKey("en", &i18n.LocalizeConfig{MessageID: "hello", TemplateData: data})
+		assert.True(t, ok)
+		return key
+	}
+	first, second := "Ann", "Ann"
+	type user struct{ Name *string }
+
+	assert.NotEqual(t, key(map[string]string{"a": "x b:y"}), key(map[string]string{"a": "x", "b": "y"}))
+	assert.NotEqual(t, key(map[string]interface{}{"n": 1}), key(map[string]interface{}{"n": "1"}))
+	assert.Equal(t, key(map[string]int{"a": 1, "b": 2, "c": 3}), key(map[string]int{"c": 3, "b": 2, "a": 1}))
+	assert.Equal(t, key(user{&first}), key(user{&second}))
+	second = "Bob"
+	assert.NotEqual(t, key(user{&first}), key(user{&second}))
+
+	_, ok := cfg.renderKey("en", &i18n.LocalizeConfig{MessageID: "hello", TemplateData: map[string]interface{}{"f": func() {}}})
+	assert.False(t, ok)
+	type node struct{ Next *node }
+	cyclic := &node{}
+	cyclic.Next = cyclic
+	_, ok = cfg.renderKey("en", &i18n.LocalizeConfig{MessageID: "hello", TemplateData: cyclic})
+	assert.False(t, ok)
+}
+
+// TestRenderCache_expiry tests expiring in-process cache entries.
+func TestRenderCache_expiry(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := newRenderCache(2, func() time.Time { return now })
+	cache.Set("a", "A", time.Minute)
+	cache.Set("b", "B", 0)
+
+	now = now.Add(time.Hour)
+	_, ok := cache.Get("a")
+	assert.False(t, ok)
+	got, ok := cache.Get("b")
+	assert.True(t, ok)
+	assert.Equal(t, "B", got)
+	assert.Equal(t, 1, cache.order.Len())
+}
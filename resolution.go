@@ -0,0 +1,65 @@
+package echoi18n
+
+import (
+	"sync"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// resolution is the state of a request stored by the middleware under
+// localsKey: the catalog snapshot serving the request and the language
+// resolved by its first negotiation against it, so the later Localize calls
+// and the response headers of the request do not run the LangHandler again.
+// The negotiation fills the resolution allocated by the middleware, so
+// negotiating does not allocate; SetLanguage clears it.
+type resolution struct {
+	catalog  *Config    // Snapshot serving the request.
+	mu       sync.Mutex // Guards resolved, as handlers may localize from several goroutines.
+	resolved resolved   // Language of the request, zero until negotiated.
+}
+
+// resolved is the language negotiated for a request.
+type resolved struct {
+	lang      string          // Supported language of the request.
+	source    string          // Detector that chose the language.
+	localizer *i18n.Localizer // Localizer of lang, nil if it has none.
+}
+
+// resolve returns the language of the request, negotiating it on the first
+// call of the request. Requests not served by the middleware of c are
+// negotiated on every call.
+func (c *Config) resolve(ctx echo.Context) resolved {
+	var r *resolution
+	if ctx != nil {
+		if r, _ = ctx.Get(localsKey).(*resolution); r != nil && r.catalog == c {
+			r.mu.Lock()
+			current := r.resolved
+			r.mu.Unlock()
+			if current.lang != "" {
+				return current
+			}
+		}
+	}
+	lang, source := c.negotiateLanguage(ctx)
+	negotiated := resolved{lang: lang, source: source}
+	if localizer, ok := c.localizerMap.Load(lang); ok {
+		negotiated.localizer = localizer.(*i18n.Localizer)
+	}
+	if r != nil && r.catalog == c {
+		r.mu.Lock()
+		r.resolved = negotiated
+		r.mu.Unlock()
+	}
+	return negotiated
+}
+
+// unresolve drops the language resolved for the request, so it is
+// negotiated again.
+func unresolve(ctx echo.Context) {
+	if r, ok := ctx.Get(localsKey).(*resolution); ok {
+		r.mu.Lock()
+		r.resolved = resolved{}
+		r.mu.Unlock()
+	}
+}
@@ -0,0 +1,88 @@
+package echoi18n
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// TestConfig_resolve tests negotiating the language once per request, and
+// again after SetLanguage.
+func TestConfig_resolve(t *testing.T) {
+	t.Parallel()
+	var calls atomic.Int32
+	app := echo.New()
+	app.Use(NewMiddleware(&Config{
+		DebugHeaders:   true,
+		CacheKeyHeader: HeaderCacheKey,
+		LangHandler: func(c echo.Context, defaultLang string) string {
+			calls.Add(1)
+			return defaultLangHandler(c, defaultLang)
+		},
+	}))
+	app.GET("/", func(c echo.Context) error {
+		first := MustLocalize(c, "welcome")
+		if user := c.QueryParam("user"); user != "" {
+			if err := SetLanguage(c, language.Make(user)); err != nil {
+				return err
+			}
+		}
+		localizer, err := GetLocalizer(c)
+		if err != nil {
+			return err
+		}
+		second := localizer.MustLocalize(&i18n.LocalizeConfig{MessageID: "welcome"})
+		return c.String(http.StatusOK, first+" "+second+" "+GetLanguage(c).String())
+	})
+
+	tests := []struct {
+		name      string
+		url       string
+		want      string
+		wantCalls int32
+	}{
+		{"negotiated once", "", "你好 你好 zh", 1},
+		{"set language", "?user=en", "你好 hello en", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls.Store(0)
+			got, err := makeRequest(language.Chinese, tt.url, app)
+			assert.NoError(t, err)
+			body, _ := io.ReadAll(got.Body)
+			assert.Equal(t, tt.want, string(body))
+			assert.Equal(t, tt.wantCalls, calls.Load())
+		})
+	}
+}
+
+// TestConfig_resolve_context tests storing the resolution of the request in
+// the resolution the middleware stored with its snapshot.
+func TestConfig_resolve_context(t *testing.T) {
+	t.Parallel()
+	cfg := &Config{}
+	NewMiddleware(cfg)
+	snapshot := cfg.active()
+	c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/?lang=zh", nil), httptest.NewRecorder())
+	r := &resolution{catalog: snapshot}
+	c.Set(localsKey, r)
+
+	got := snapshot.resolve(c)
+	assert.Equal(t, "zh", got.lang)
+	assert.Equal(t, got, r.resolved)
+	assert.Same(t, r, c.Get(localsKey))
+	assert.Equal(t, got, snapshot.resolve(c))
+
+	unresolve(c)
+	assert.Equal(t, resolved{}, r.resolved)
+	config, err := getConfig(c)
+	assert.NoError(t, err)
+	assert.Same(t, snapshot, config)
+}
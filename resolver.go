@@ -0,0 +1,121 @@
+package echoi18n
+
+import (
+	"fmt"
+
+	"github.com/labstack/echo/v4"
+)
+
+// LanguageResolver detects the language requested by a request. The default
+// LangHandler tries the Resolvers in order and serves the first language
+// found, or the default language if none is. Resolvers doing I/O must honor
+// the request context, which carries the DetectTimeout deadline.
+type LanguageResolver interface {
+	ResolveLanguage(c echo.Context) (string, bool)
+}
+
+// ResolverFunc is an adapter to use a function as a LanguageResolver. Its
+// languages are reported with SourceHandler.
+type ResolverFunc func(c echo.Context) (string, bool)
+
+// ResolveLanguage calls f(c).
+func (f ResolverFunc) ResolveLanguage(c echo.Context) (string, bool) {
+	return f(c)
+}
+
+// builtinResolver is a built-in LanguageResolver. Its resolve function
+// reports the source of the language it finds.
+type builtinResolver struct {
+	name    string
+	resolve func(c echo.Context) (string, bool)
+}
+
+// ResolveLanguage calls the resolve function.
+func (r *builtinResolver) ResolveLanguage(c echo.Context) (string, bool) {
+	return r.resolve(c)
+}
+
+// Built-in language resolvers, in their default order.
+var (
+	// PathResolver reads the path prefix stripped by StripLanguagePrefix, or
+	// the one of the request path with PathLanguage.
+	PathResolver LanguageResolver = &builtinResolver{"path", resolvePath}
+	// QueryResolver reads the "lang" query parameter.
+	QueryResolver LanguageResolver = &builtinResolver{"query", resolveQuery}
+	// CookieResolver reads the language cookie.
+	CookieResolver LanguageResolver = &builtinResolver{"cookie", resolveCookie}
+	// HeaderResolver matches the Accept-Language header against AcceptLanguages.
+	HeaderResolver LanguageResolver = &builtinResolver{"header", resolveHeader}
+)
+
+// defaultResolvers are the resolvers of the default LangHandler without Resolvers.
+var defaultResolvers = []LanguageResolver{PathResolver, QueryResolver, CookieResolver, HeaderResolver}
+
+// resolverName names a resolver in the resolution trace: the source of a
+// built-in resolver, the type of the others.
+func resolverName(resolver LanguageResolver) string {
+	if builtin, ok := resolver.(*builtinResolver); ok {
+		return builtin.name
+	}
+	return fmt.Sprintf("%T", resolver)
+}
+
+// found reports a language found by a built-in resolver and its source.
+// Sources are constants so that reporting them does not allocate.
+func found(c echo.Context, lang, source string) (string, bool) {
+	c.Set(sourceKey, source)
+	return lang, true
+}
+
+// resolvePath returns the language of the request path prefix.
+func resolvePath(c echo.Context) (string, bool) {
+	appCfg, err := getConfig(c)
+	if err != nil {
+		return "", false
+	}
+	if lang, ok := appCfg.requestPathLanguage(c); ok {
+		return found(c, lang, SourcePath)
+	}
+	return "", false
+}
+
+// resolveQuery returns the language of the "lang" query parameter.
+func resolveQuery(c echo.Context) (string, bool) {
+	if lang := queryParam(c, "lang"); lang != "" {
+		return found(c, lang, SourceQuery)
+	}
+	return "", false
+}
+
+// resolveCookie returns the language of the language cookie.
+func resolveCookie(c echo.Context) (string, bool) {
+	cookieName := "lang"
+	if appCfg, err := getConfig(c); err == nil {
+		cookieName = appCfg.CookieName
+	}
+	if lang := cookieValue(c, cookieName); lang != "" {
+		return found(c, lang, SourceCookie)
+	}
+	return "", false
+}
+
+// resolveHeader returns the supported language best matching the
+// Accept-Language header, ignoring the BetaLanguages the request may not
+// negotiate.
+func resolveHeader(c echo.Context) (string, bool) {
+	header := c.Request().Header.Get("Accept-Language")
+	if header == "" {
+		return "", false
+	}
+	accept := defaultAcceptMatcher
+	if appCfg, err := getConfig(c); err == nil {
+		accept = appCfg.acceptMatcher()
+		if appCfg.publicAccept != nil && !appCfg.betaAllowed(c) {
+			accept = appCfg.publicAccept
+		}
+	}
+	if lang, ok := accept.match(header); ok {
+		return found(c, lang, SourceHeader)
+	}
+	return "", false
+}
@@ -0,0 +1,60 @@
+package echoi18n
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestConfig_Resolvers tests detecting the language with a custom resolver chain.
+func TestConfig_Resolvers(t *testing.T) {
+	t.Parallel()
+	tenant := ResolverFunc(func(c echo.Context) (string, bool) {
+		lang := c.Request().Header.Get("X-Tenant-Language")
+		return lang, lang != ""
+	})
+	app := echo.New()
+	app.Use(NewMiddleware(&Config{
+		Resolvers:    []LanguageResolver{CookieResolver, tenant, HeaderResolver},
+		DebugHeaders: true,
+	}))
+	app.GET("/", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	tests := []struct {
+		name       string
+		url        string
+		header     string
+		tenant     string
+		cookie     string
+		wantLang   string
+		wantSource string
+	}{
+		{"cookie first", "/", "en", "en", "lang=zh", "zh", SourceCookie},
+		{"custom resolver", "/", "en", "zh", "", "zh", SourceHandler},
+		{"header", "/", "zh", "", "", "zh", SourceHeader},
+		{"query not configured", "/?lang=zh", "", "", "", "en", SourceDefault},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			if tt.header != "" {
+				req.Header.Set("Accept-Language", tt.header)
+			}
+			if tt.tenant != "" {
+				req.Header.Set("X-Tenant-Language", tt.tenant)
+			}
+			if tt.cookie != "" {
+				req.Header.Set("Cookie", tt.cookie)
+			}
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+			assert.Equal(t, tt.wantLang, rec.Header().Get(HeaderLanguage))
+			assert.Equal(t, tt.wantSource, rec.Header().Get(HeaderSource))
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package echoi18n
+
+import (
+	"encoding/json"
+	"reflect"
+	"sync"
+
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	messageRefType = reflect.TypeOf(MessageRef{})
+	marshalerType  = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// refTypes caches whether values of a type may hold a MessageRef.
+var refTypes sync.Map
+
+// LocalizedString localizes a message and writes it as a plain text response.
+func LocalizedString(c echo.Context, code int, params interface{}) error {
+	message, err := Localize(c, params)
+	if err != nil {
+		return err
+	}
+	return c.String(code, message)
+}
+
+// LocalizedJSON writes payload as a JSON response, with the MessageRef values
+// it holds in struct fields, maps, slices and pointers replaced by their
+// localized message. The payload is encoded by encoding/json as with c.JSON:
+// the references are localized in a copy of the parts of the payload holding
+// them. Values implementing json.Marshaler, fields tagged "-" and the fields
+// of unexported embedded structs are kept as is.
+func LocalizedJSON(c echo.Context, code int, payload interface{}) error {
+	localized, err := localizeRefs(c, reflect.ValueOf(payload))
+	if err != nil {
+		return err
+	}
+	if !localized.IsValid() {
+		return c.JSON(code, nil)
+	}
+	return c.JSON(code, localized.Interface())
+}
+
+// holdsRef reports whether values of a type may hold a MessageRef.
+func holdsRef(t reflect.Type) bool {
+	if holds, ok := refTypes.Load(t); ok {
+		return holds.(bool)
+	}
+	holds := searchRef(t, map[reflect.Type]bool{})
+	refTypes.Store(t, holds)
+	return holds
+}
+
+// searchRef searches a MessageRef in the types reachable from t.
+func searchRef(t reflect.Type, visited map[reflect.Type]bool) bool {
+	if visited[t] {
+		return false
+	}
+	visited[t] = true
+	switch t.Kind() {
+	case reflect.Interface:
+		return true
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		return searchRef(t.Elem(), visited)
+	case reflect.Struct:
+		if t == messageRefType {
+			return true
+		}
+		for i := 0; i < t.NumField(); i++ {
+			if field := t.Field(i); field.IsExported() && searchRef(field.Type, visited) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// localizeRefs returns a copy of v with its MessageRef values localized.
+// Values holding no MessageRef are returned as is.
+func localizeRefs(c echo.Context, v reflect.Value) (reflect.Value, error) {
+	if !v.IsValid() || !holdsRef(v.Type()) {
+		return v, nil
+	}
+	t := v.Type()
+	if t == messageRefType {
+		ref := v.Interface().(MessageRef)
+		message, err := Localize(c, ref)
+		if err != nil {
+			return v, err
+		}
+		ref.localized = &message
+		return reflect.ValueOf(ref), nil
+	}
+	if t.Implements(marshalerType) || v.CanAddr() && reflect.PtrTo(t).Implements(marshalerType) {
+		return v, nil
+	}
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return v, nil
+		}
+		elem, err := localizeRefs(c, v.Elem())
+		if err != nil {
+			return v, err
+		}
+		if v.Kind() == reflect.Interface {
+			copied := reflect.New(t).Elem()
+			copied.Set(elem)
+			return copied, nil
+		}
+		copied := reflect.New(t.Elem())
+		copied.Elem().Set(elem)
+		return copied, nil
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return v, nil
+		}
+		copied := reflect.New(t).Elem()
+		if v.Kind() == reflect.Slice {
+			copied = reflect.MakeSlice(t, v.Len(), v.Len())
+		}
+		for i := 0; i < v.Len(); i++ {
+			item, err := localizeRefs(c, v.Index(i))
+			if err != nil {
+				return v, err
+			}
+			copied.Index(i).Set(item)
+		}
+		return copied, nil
+	case reflect.Map:
+		if v.IsNil() {
+			return v, nil
+		}
+		copied := reflect.MakeMapWithSize(t, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			value, err := localizeRefs(c, iter.Value())
+			if err != nil {
+				return v, err
+			}
+			copied.SetMapIndex(iter.Key(), value)
+		}
+		return copied, nil
+	case reflect.Struct:
+		copied := reflect.New(t).Elem()
+		copied.Set(v)
+		for i := 0; i < t.NumField(); i++ {
+			field := copied.Field(i)
+			if !field.CanSet() || t.Field(i).Tag.Get("json") == "-" {
+				continue
+			}
+			localized, err := localizeRefs(c, v.Field(i))
+			if err != nil {
+				return v, err
+			}
+			field.Set(localized)
+		}
+		return copied, nil
+	}
+	return v, nil
+}
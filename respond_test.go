@@ -0,0 +1,98 @@
+package echoi18n
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// Page is embedded in payloads, exported so LocalizedJSON inlines its fields.
+type Page struct {
+	Title MessageRef `json:"title"`
+}
+
+// product is a LocalizedJSON payload.
+type product struct {
+	Page
+	ID       int                   `json:"id"`
+	Name     *MessageRef           `json:"name,omitempty"`
+	Badges   []MessageRef          `json:"badges"`
+	Labels   map[string]MessageRef `json:"labels,omitempty"`
+	Extra    interface{}           `json:"extra"`
+	Internal string                `json:"-"`
+}
+
+// stamp encodes itself with a pointer receiver.
+type stamp struct{ at string }
+
+// MarshalJSON encodes the stamp time.
+func (s *stamp) MarshalJSON() ([]byte, error) {
+	return json.Marshal("at " + s.at)
+}
+
+// listing is a LocalizedJSON payload using the encoding/json features kept
+// from c.JSON.
+type listing struct {
+	*Page
+	Title  string                      `json:"title"`
+	Count  int                         `json:"count,string"`
+	ByLang map[language.Tag]MessageRef `json:"by_lang"`
+	Stamp  stamp                       `json:"stamp"`
+}
+
+// TestLocalizedJSON tests writing payloads with localized message references.
+func TestLocalizedJSON(t *testing.T) {
+	t.Parallel()
+	app := echo.New()
+	app.Use(NewMiddleware(&Config{}))
+	app.GET("/", func(c echo.Context) error {
+		return LocalizedJSON(c, http.StatusOK, []product{{
+			Page:     Page{Title: MessageRef{ID: "welcome"}},
+			ID:       1,
+			Badges:   []MessageRef{{ID: "welcomeWithName", TemplateData: map[string]string{"name": "Ann"}}},
+			Extra:    map[string]interface{}{"hint": MessageRef{ID: "welcome"}, "count": 2},
+			Internal: "secret",
+		}})
+	})
+	app.GET("/listing", func(c echo.Context) error {
+		return LocalizedJSON(c, http.StatusOK, &listing{
+			Page:   &Page{Title: MessageRef{ID: "welcome"}},
+			Title:  "shadowing",
+			Count:  3,
+			ByLang: map[language.Tag]MessageRef{language.English: {ID: "welcome"}},
+			Stamp:  stamp{at: "noon"},
+		})
+	})
+	app.GET("/string", func(c echo.Context) error {
+		return LocalizedString(c, http.StatusCreated, "welcome")
+	})
+	app.GET("/missing", func(c echo.Context) error {
+		return LocalizedJSON(c, http.StatusOK, map[string]MessageRef{"title": {ID: "missing"}})
+	})
+
+	tests := []struct {
+		name       string
+		url        string
+		wantStatus int
+		wantBody   string
+	}{
+		{"json", "", http.StatusOK, `[{"title":"你好","id":1,"badges":["你好 Ann"],"extra":{"count":2,"hint":"你好"}}]` + "\n"},
+		{"encoding/json", "listing", http.StatusOK, `{"title":"shadowing","count":"3","by_lang":{"en":"你好"},"stamp":"at noon"}` + "\n"},
+		{"string", "string", http.StatusCreated, "你好"},
+		{"error", "missing", http.StatusInternalServerError, `{"message":"Internal Server Error"}` + "\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := makeRequest(language.Chinese, tt.url, app)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, got.StatusCode)
+			body, _ := io.ReadAll(got.Body)
+			assert.Equal(t, tt.wantBody, string(body))
+		})
+	}
+}
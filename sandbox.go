@@ -0,0 +1,119 @@
+package echoi18n
+
+import (
+	"fmt"
+	"text/template/parse"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n/template"
+)
+
+// sandbox restricts message templates to an allowlist of functions and
+// forbids actions that can recurse or loop. Fields and methods of the
+// template data are not restricted: a template can call any exported method
+// of the data it is given.
+type sandbox struct {
+	allowed map[string]bool
+}
+
+// newSandbox creates a sandbox allowing the given functions, or nil if
+// AllowedFuncs is not set.
+func (c *Config) newSandbox() *sandbox {
+	if c.AllowedFuncs == nil {
+		return nil
+	}
+	allowed := make(map[string]bool, len(c.AllowedFuncs))
+	for _, name := range c.AllowedFuncs {
+		allowed[name] = true
+	}
+	return &sandbox{allowed: allowed}
+}
+
+// check parses src and reports the first forbidden construct it contains.
+// Functions are checked against the allowlist only, whatever the parser
+// defines.
+func (s *sandbox) check(src, leftDelim, rightDelim string) error {
+	tree := parse.New("")
+	tree.Mode = parse.SkipFuncCheck
+	trees := map[string]*parse.Tree{}
+	if _, err := tree.Parse(src, leftDelim, rightDelim, trees); err != nil {
+		return err
+	}
+	for name := range trees {
+		if name != "" {
+			return fmt.Errorf("sandbox: define and block actions are not allowed")
+		}
+	}
+	return s.checkNode(tree.Root)
+}
+
+// sandboxedParser checks message templates in the sandbox before parsing
+// them with the functions or parser of a LocalizeConfig.
+type sandboxedParser struct {
+	template.Parser
+	sandbox *sandbox
+}
+
+// Parse checks the message template, then parses it.
+func (p *sandboxedParser) Parse(src, leftDelim, rightDelim string) (template.ParsedTemplate, error) {
+	if err := p.sandbox.check(src, leftDelim, rightDelim); err != nil {
+		return nil, err
+	}
+	return p.Parser.Parse(src, leftDelim, rightDelim)
+}
+
+// checkNode walks a template parse tree.
+func (s *sandbox) checkNode(node parse.Node) error {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+		for _, child := range n.Nodes {
+			if err := s.checkNode(child); err != nil {
+				return err
+			}
+		}
+	case *parse.ActionNode:
+		return s.checkNode(n.Pipe)
+	case *parse.PipeNode:
+		if n == nil {
+			return nil
+		}
+		for _, cmd := range n.Cmds {
+			if err := s.checkNode(cmd); err != nil {
+				return err
+			}
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			if err := s.checkNode(arg); err != nil {
+				return err
+			}
+		}
+	case *parse.IdentifierNode:
+		if !s.allowed[n.Ident] {
+			return fmt.Errorf("sandbox: function %q is not allowed", n.Ident)
+		}
+	case *parse.ChainNode:
+		return s.checkNode(n.Node)
+	case *parse.IfNode:
+		return s.checkBranch(&n.BranchNode)
+	case *parse.WithNode:
+		return s.checkBranch(&n.BranchNode)
+	case *parse.RangeNode:
+		return fmt.Errorf("sandbox: range actions are not allowed")
+	case *parse.TemplateNode:
+		return fmt.Errorf("sandbox: template actions are not allowed")
+	}
+	return nil
+}
+
+// checkBranch walks the pipeline and lists of an if or with action.
+func (s *sandbox) checkBranch(n *parse.BranchNode) error {
+	for _, node := range []parse.Node{n.Pipe, n.List, n.ElseList} {
+		if err := s.checkNode(node); err != nil {
+			return err
+		}
+	}
+	return nil
+}
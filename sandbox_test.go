@@ -0,0 +1,115 @@
+package echoi18n
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	i18ntemplate "github.com/nicksnyder/go-i18n/v2/i18n/template"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// Test_sandbox_check tests the forbidden template constructs.
+func Test_sandbox_check(t *testing.T) {
+	s := &sandbox{allowed: map[string]bool{"upper": true, "eq": true}}
+
+	tests := []struct {
+		name string
+		src  string
+		err  string
+	}{
+		{"plain text", "hello", ""},
+		{"allowed function", "{{ upper .name }}", ""},
+		{"conditional", `{{ if eq .n 1 }}one{{ else }}{{ upper .name }}{{ end }}`, ""},
+		{"unlisted function", "{{ lower .name }}", `sandbox: function "lower" is not allowed`},
+		{"undefined function", "{{ shout .name }}", `sandbox: function "shout" is not allowed`},
+		{"builtin call", "{{ call .fn }}", `sandbox: function "call" is not allowed`},
+		{"nested unlisted function", `{{ with .user }}{{ printf "%s" .name }}{{ end }}`, `sandbox: function "printf" is not allowed`},
+		{"range", "{{ range .items }}x{{ end }}", "sandbox: range actions are not allowed"},
+		{"define", `{{ define "x" }}x{{ end }}`, "sandbox: define and block actions are not allowed"},
+		{"template", `{{ template "x" }}`, "sandbox: template actions are not allowed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.check(tt.src, "", "")
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
+
+// TestConfig_AllowedFuncs tests rejecting sandbox violations at load.
+func TestConfig_AllowedFuncs(t *testing.T) {
+	t.Parallel()
+	assert.PanicsWithError(t, `i18n.NewMiddleware error: en: message "shout" in language "en": sandbox: function "upper" is not allowed`, func() {
+		NewMiddleware(&Config{
+			Funcs:        template.FuncMap{"upper": strings.ToUpper},
+			AllowedFuncs: []string{},
+			Bundles:      []Bundle{{"en": {{ID: "shout", Other: "{{ upper .name }}!"}}}},
+		})
+	})
+	assert.NotPanics(t, func() {
+		NewMiddleware(&Config{
+			Funcs:        template.FuncMap{"upper": strings.ToUpper},
+			AllowedFuncs: []string{"upper"},
+			Bundles:      []Bundle{{"en": {{ID: "shout", Other: "{{ upper .name }}!"}}}},
+		})
+	})
+}
+
+// TestConfig_AllowedFuncs_localizeConfig tests sandboxing the templates
+// parsed with the functions or parser of a LocalizeConfig.
+func TestConfig_AllowedFuncs_localizeConfig(t *testing.T) {
+	t.Parallel()
+	funcs := template.FuncMap{"upper": strings.ToUpper, "lower": strings.ToLower}
+	app := echo.New()
+	app.Use(NewMiddleware(&Config{
+		Funcs:        template.FuncMap{"upper": strings.ToUpper},
+		AllowedFuncs: []string{"upper"},
+	}))
+	app.GET("/", func(c echo.Context) error {
+		lc := &i18n.LocalizeConfig{
+			DefaultMessage: &i18n.Message{ID: "greeting", Other: "{{ " + c.QueryParam("func") + " .name }}"},
+			TemplateData:   map[string]string{"name": "Alex"},
+		}
+		if c.QueryParam("parser") != "" {
+			lc.TemplateParser = &i18ntemplate.TextParser{Funcs: funcs}
+		} else {
+			lc.Funcs = funcs
+		}
+		message, err := Localize(c, lc)
+		if err != nil {
+			return c.String(http.StatusInternalServerError, err.Error())
+		}
+		return c.String(http.StatusOK, message)
+	})
+
+	tests := []struct {
+		name string
+		url  string
+		code int
+		want string
+	}{
+		{"allowed function", "?func=upper", http.StatusOK, "ALEX"},
+		{"funcs", "?func=lower", http.StatusInternalServerError, `sandbox: function "lower" is not allowed`},
+		{"template parser", "?func=lower&parser=1", http.StatusInternalServerError, `sandbox: function "lower" is not allowed`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := makeRequest(language.English, tt.url, app)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.code, got.StatusCode)
+			body, _ := io.ReadAll(got.Body)
+			assert.Contains(t, string(body), tt.want)
+		})
+	}
+}
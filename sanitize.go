@@ -0,0 +1,57 @@
+package echoi18n
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// Sanitizer rewrites a template data value of a message in lang before
+// interpolation, e.g. to trim, escape or mask it.
+type Sanitizer func(lang, key string, value interface{}) interface{}
+
+// TrimSpace is a Sanitizer trimming the white space around string values.
+func TrimSpace(_, _ string, value interface{}) interface{} {
+	if s, ok := value.(string); ok {
+		return strings.TrimSpace(s)
+	}
+	return value
+}
+
+// EscapeHTML is a Sanitizer escaping the HTML special characters of string
+// and Stringer values, for messages rendered into HTML as is.
+func EscapeHTML(_, _ string, value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		return html.EscapeString(v)
+	case fmt.Stringer:
+		return html.EscapeString(v.String())
+	}
+	return value
+}
+
+// MaskKeys returns a Sanitizer replacing the values of the given template data
+// keys with mask, e.g. to keep emails or phone numbers out of messages.
+func MaskKeys(mask string, keys ...string) Sanitizer {
+	masked := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		masked[key] = true
+	}
+	return func(_, key string, value interface{}) interface{} {
+		if masked[key] {
+			return mask
+		}
+		return value
+	}
+}
+
+// sanitize applies the Sanitizers to map and struct template data. They are
+// set on the Config and apply to the messages of every bundle alike.
+func (c *Config) sanitize(lang string, data interface{}) interface{} {
+	return mapTemplateData(data, func(key string, value interface{}) interface{} {
+		for _, sanitizer := range c.Sanitizers {
+			value = sanitizer(lang, key, value)
+		}
+		return value
+	})
+}
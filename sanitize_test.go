@@ -0,0 +1,48 @@
+package echoi18n
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// TestConfig_Sanitizers tests sanitizing template data before interpolation.
+func TestConfig_Sanitizers(t *testing.T) {
+	t.Parallel()
+	app := echo.New()
+	app.Use(NewMiddleware(&Config{
+		Bundles: []Bundle{{
+			"en": {
+				{ID: "contact", Other: "{{ .name }} <{{ .email }}>"},
+				{ID: "profile", Other: "{{ .Name }} <{{ .Email }}>"},
+			},
+		}},
+		Sanitizers: []Sanitizer{TrimSpace, EscapeHTML, MaskKeys("***", "email", "Email")},
+	}))
+	app.GET("/", func(c echo.Context) error {
+		return c.String(http.StatusOK, MustLocalize(c, &i18n.LocalizeConfig{
+			MessageID:    "contact",
+			TemplateData: map[string]string{"name": "  Ann & Bob ", "email": "ann@example.com"},
+		}))
+	})
+	app.GET("/profile", func(c echo.Context) error {
+		return c.String(http.StatusOK, MustLocalize(c, &i18n.LocalizeConfig{
+			MessageID: "profile",
+			TemplateData: &struct {
+				Name, Email string
+			}{"  Ann & Bob ", "ann@example.com"},
+		}))
+	})
+
+	for _, url := range []string{"", "profile"} {
+		got, err := makeRequest(language.English, url, app)
+		assert.NoError(t, err)
+		body, _ := io.ReadAll(got.Body)
+		assert.Equal(t, "Ann &amp; Bob <***>", string(body))
+	}
+}
@@ -0,0 +1,142 @@
+package echoi18n
+
+import (
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// TestConfig_Schedule tests activating a staged catalog at its activation time.
+func TestConfig_Schedule(t *testing.T) {
+	t.Parallel()
+	var mu sync.Mutex
+	now := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	loader := &reloadLoader{}
+	loader.set("welcome: Welcome", nil)
+	cfg := &Config{
+		AcceptLanguages: []language.Tag{language.English},
+		Loader:          loader,
+		RootPath:        "localize",
+		Clock: func() time.Time {
+			mu.Lock()
+			defer mu.Unlock()
+			return now
+		},
+	}
+	app := echo.New()
+	app.Use(NewMiddleware(cfg))
+	app.GET("/", func(c echo.Context) error {
+		return LocalizedString(c, http.StatusOK, "welcome")
+	})
+	get := func() string {
+		resp, err := makeRequest(language.English, "", app)
+		assert.NoError(t, err)
+		body, _ := io.ReadAll(resp.Body)
+		return string(body)
+	}
+	current := cfg.Version()
+
+	loader.set("welcome: Launched", nil)
+	launch := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	version, err := cfg.Schedule(launch)
+	assert.NoError(t, err)
+	assert.NotEqual(t, current, version)
+	got, at, ok := cfg.Scheduled()
+	assert.True(t, ok)
+	assert.Equal(t, version, got)
+	assert.Equal(t, launch, at)
+
+	assert.Equal(t, "Welcome", get())
+	assert.Equal(t, current, cfg.Version())
+
+	mu.Lock()
+	now = launch
+	mu.Unlock()
+	assert.Equal(t, "Launched", get())
+	assert.Equal(t, version, cfg.Version())
+	_, _, ok = cfg.Scheduled()
+	assert.False(t, ok)
+
+	loader.set("welcome: [broken", nil)
+	_, err = cfg.Schedule(launch)
+	assert.Error(t, err)
+	_, _, ok = cfg.Scheduled()
+	assert.False(t, ok)
+}
+
+// TestConfig_Schedule_reload tests reloading while a catalog is staged.
+func TestConfig_Schedule_reload(t *testing.T) {
+	t.Parallel()
+	var mu sync.Mutex
+	now := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	loader := &reloadLoader{}
+	loader.set("welcome: Welcome", nil)
+	cfg := &Config{
+		AcceptLanguages: []language.Tag{language.English},
+		Loader:          loader,
+		RootPath:        "localize",
+		Clock: func() time.Time {
+			mu.Lock()
+			defer mu.Unlock()
+			return now
+		},
+	}
+	app := echo.New()
+	app.Use(NewMiddleware(cfg))
+	app.GET("/", func(c echo.Context) error {
+		return LocalizedString(c, http.StatusOK, "welcome")
+	})
+	get := func() string {
+		resp, err := makeRequest(language.English, "", app)
+		assert.NoError(t, err)
+		body, _ := io.ReadAll(resp.Body)
+		return string(body)
+	}
+
+	loader.set("welcome: Launched", nil)
+	launch := now.Add(time.Hour)
+	_, err := cfg.Schedule(launch)
+	assert.NoError(t, err)
+
+	loader.set("welcome: Launched!", nil)
+	assert.NoError(t, cfg.Reload())
+	assert.Equal(t, "Welcome", get())
+	version, at, ok := cfg.Scheduled()
+	assert.True(t, ok)
+	assert.Equal(t, launch, at)
+
+	mu.Lock()
+	now = launch
+	mu.Unlock()
+	assert.Equal(t, "Launched!", get())
+	assert.Equal(t, version, cfg.Version())
+
+	loader.set("welcome: Fixed", nil)
+	assert.NoError(t, cfg.Reload())
+	assert.Equal(t, "Fixed", get())
+}
+
+// TestConfig_CancelSchedule tests dropping a staged catalog.
+func TestConfig_CancelSchedule(t *testing.T) {
+	t.Parallel()
+	loader := &reloadLoader{}
+	loader.set("welcome: Welcome", nil)
+	cfg := &Config{AcceptLanguages: []language.Tag{language.English}, Loader: loader, RootPath: "localize"}
+	app := echo.New()
+	app.Use(NewMiddleware(cfg))
+	current := cfg.Version()
+
+	loader.set("welcome: Launched", nil)
+	_, err := cfg.Schedule(time.Now().Add(-time.Minute))
+	assert.NoError(t, err)
+	assert.NoError(t, cfg.CancelSchedule())
+	_, err = makeRequest(language.English, "", app)
+	assert.NoError(t, err)
+	assert.Equal(t, current, cfg.Version())
+}
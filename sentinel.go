@@ -0,0 +1,67 @@
+package echoi18n
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ErrorMessage is the localized answer of a domain error registered with
+// RegisterError.
+type ErrorMessage struct {
+	Status  int        // HTTP status of the answer, 500 when 0.
+	Message MessageRef // Message answering the error.
+}
+
+// errorMessages are the registered error messages, matched in registration order.
+var errorMessages = struct {
+	sync.RWMutex
+	targets  []error
+	messages []ErrorMessage
+}{}
+
+// RegisterError registers the message answering errors matching target with
+// errors.Is, such as sql.ErrNoRows or context.DeadlineExceeded, so that
+// HTTPErrorHandler localizes them uniformly. Registering a target again
+// replaces its message.
+func RegisterError(target error, message ErrorMessage) {
+	errorMessages.Lock()
+	defer errorMessages.Unlock()
+	for i, registered := range errorMessages.targets {
+		if registered == target {
+			errorMessages.messages[i] = message
+			return
+		}
+	}
+	errorMessages.targets = append(errorMessages.targets, target)
+	errorMessages.messages = append(errorMessages.messages, message)
+}
+
+// LookupError returns the message of the first registered target err matches.
+func LookupError(err error) (ErrorMessage, bool) {
+	errorMessages.RLock()
+	defer errorMessages.RUnlock()
+	for i, target := range errorMessages.targets {
+		if errors.Is(err, target) {
+			return errorMessages.messages[i], true
+		}
+	}
+	return ErrorMessage{}, false
+}
+
+// registeredHTTPError converts a registered domain error to an HTTP error
+// with the localized message as body, or the status text if it fails to
+// localize.
+func registeredHTTPError(c echo.Context, err error, message ErrorMessage) *echo.HTTPError {
+	status := message.Status
+	if status == 0 {
+		status = http.StatusInternalServerError
+	}
+	text, localizeErr := Localize(c, message.Message)
+	if localizeErr != nil {
+		text = http.StatusText(status)
+	}
+	return echo.NewHTTPError(status, text).SetInternal(err)
+}
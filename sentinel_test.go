@@ -0,0 +1,77 @@
+package echoi18n
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// Sentinel errors of the registry tests.
+var (
+	errTestNotFound = errors.New("record not found")
+	errTestTimeout  = errors.New("timeout")
+)
+
+// restoreErrors restores the error registry when the test ends. Tests
+// registering errors do not run in parallel, so the others never see them.
+func restoreErrors(t *testing.T) {
+	errorMessages.RLock()
+	targets := append([]error(nil), errorMessages.targets...)
+	messages := append([]ErrorMessage(nil), errorMessages.messages...)
+	errorMessages.RUnlock()
+	t.Cleanup(func() {
+		errorMessages.Lock()
+		defer errorMessages.Unlock()
+		errorMessages.targets, errorMessages.messages = targets, messages
+	})
+}
+
+// TestRegisterError tests answering registered domain errors with localized messages.
+func TestRegisterError(t *testing.T) {
+	restoreErrors(t)
+	RegisterError(errTestNotFound, ErrorMessage{Status: http.StatusNotFound, Message: MessageRef{ID: "notFound"}})
+	RegisterError(errTestTimeout, ErrorMessage{Message: MessageRef{ID: "missing"}})
+
+	app := echo.New()
+	app.HTTPErrorHandler = HTTPErrorHandler(app.DefaultHTTPErrorHandler)
+	app.Use(NewMiddleware(&Config{
+		Bundles: []Bundle{{
+			"en": {{ID: "notFound", Other: "Not found"}},
+			"zh": {{ID: "notFound", Other: "未找到"}},
+		}},
+	}))
+	app.GET("/", func(c echo.Context) error {
+		return fmt.Errorf("load order: %w", errTestNotFound)
+	})
+	app.GET("/timeout", func(c echo.Context) error {
+		return errTestTimeout
+	})
+
+	tests := []struct {
+		name       string
+		url        string
+		wantStatus int
+		wantBody   string
+	}{
+		{"wrapped", "", http.StatusNotFound, `{"message":"未找到"}`},
+		{"status text", "timeout", http.StatusInternalServerError, `{"message":"Internal Server Error"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := makeRequest(language.Chinese, tt.url, app)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, got.StatusCode)
+			body, _ := io.ReadAll(got.Body)
+			assert.JSONEq(t, tt.wantBody, string(body))
+		})
+	}
+
+	_, ok := LookupError(errors.New("record not found"))
+	assert.False(t, ok)
+}
@@ -0,0 +1,252 @@
+package echoi18n
+
+import (
+	"container/list"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// shard is the catalog of a namespace, loaded separately from the main one.
+type shard struct {
+	namespace  string                     // Namespace of the messages.
+	localizers map[string]*i18n.Localizer // Localizers for each supported language.
+	memory     map[string]MemoryStats     // Memory used by the messages of each language.
+	chains     sync.Map                   // Localizers of the fallback chain of each language.
+}
+
+// shardCache keeps the loaded shards, evicting the least recently used ones
+// beyond max shards. The shards of the pinned namespaces are kept outside
+// the LRU: they are never evicted by it nor counted against max.
+type shardCache struct {
+	mu     sync.Mutex
+	max    int                      // Maximum number of unpinned shards, unbounded when 0.
+	order  *list.List               // Unpinned shards, most recently used first.
+	shards map[string]*list.Element // Elements of order keyed by namespace.
+	pins   []string                 // Pinned namespaces, in order.
+	pinned map[string]*shard        // Loaded shards of the pinned namespaces.
+}
+
+// newShardCache creates a cache of at most max shards, unbounded when 0,
+// besides those of the pinned namespaces.
+func newShardCache(max int, pins []string) *shardCache {
+	pinned := make(map[string]*shard, len(pins))
+	return &shardCache{max: max, order: list.New(), shards: map[string]*list.Element{}, pins: pins, pinned: pinned}
+}
+
+// isPinned reports whether the namespace is pinned.
+func (s *shardCache) isPinned(namespace string) bool {
+	for _, pin := range s.pins {
+		if pin == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// get returns the cached shard of a namespace and marks it as recently used.
+func (s *shardCache) get(namespace string) (*shard, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if sh, ok := s.pinned[namespace]; ok {
+		return sh, true
+	}
+	elem, ok := s.shards[namespace]
+	if !ok {
+		return nil, false
+	}
+	s.order.MoveToFront(elem)
+	return elem.Value.(*shard), true
+}
+
+// add caches a shard, evicting the least recently used ones over the limit.
+// A shard added concurrently for the same namespace is kept.
+func (s *shardCache) add(sh *shard) *shard {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.isPinned(sh.namespace) {
+		if cached, ok := s.pinned[sh.namespace]; ok {
+			return cached
+		}
+		s.pinned[sh.namespace] = sh
+		return sh
+	}
+	if elem, ok := s.shards[sh.namespace]; ok {
+		s.order.MoveToFront(elem)
+		return elem.Value.(*shard)
+	}
+	s.shards[sh.namespace] = s.order.PushFront(sh)
+	for s.max > 0 && s.order.Len() > s.max {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.shards, oldest.Value.(*shard).namespace)
+	}
+	return sh
+}
+
+// remove evicts the shard of a namespace, pinned or not.
+func (s *shardCache) remove(namespace string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.pinned, namespace)
+	if elem, ok := s.shards[namespace]; ok {
+		s.order.Remove(elem)
+		delete(s.shards, namespace)
+	}
+}
+
+// full reports whether the LRU holds max shards.
+func (s *shardCache) full() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.max > 0 && s.order.Len() >= s.max
+}
+
+// all returns the cached shards: the pinned ones in order, then the others
+// most recently used first.
+func (s *shardCache) all() []*shard {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	shards := make([]*shard, 0, len(s.pinned)+s.order.Len())
+	for _, pin := range s.pins {
+		if sh, ok := s.pinned[pin]; ok {
+			shards = append(shards, sh)
+		}
+	}
+	for elem := s.order.Front(); elem != nil; elem = elem.Next() {
+		shards = append(shards, elem.Value.(*shard))
+	}
+	return shards
+}
+
+// isNamespace reports whether the namespace is configured in Namespaces.
+func (c *Config) isNamespace(namespace string) bool {
+	for _, ns := range c.Namespaces {
+		if ns == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// loadShard loads the <RootPath>/<namespace>/<lang>.<format> message files of
+// a namespace into a bundle of its own. Languages without a file fall back
+// to the default language.
+func (c *Config) loadShard(namespace string) (*shard, error) {
+	bundle := i18n.NewBundle(c.DefaultLanguage)
+	memory := make(map[string]MemoryStats, len(c.AcceptLanguages))
+	for _, tag := range c.AcceptLanguages {
+		bundleFilePath := fmt.Sprintf("%s%s.%s", c.FilePrefix, tag.String(), c.languageFormat(tag.String()))
+		filepath := path.Join(c.RootPath, namespace, bundleFilePath)
+		buf, err := c.Loader.LoadMessage(filepath)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		messageFile, err := i18n.ParseMessageFileBytes(buf, filepath, c.unmarshalFuncs())
+		if err != nil {
+			return nil, err
+		}
+		messages := c.normalizeMessages(messageFile.Messages)
+		if err := bundle.AddMessages(messageFile.Tag, messages...); err != nil {
+			return nil, err
+		}
+		memory[messageFile.Tag.String()] = c.messageMemory(messages)
+	}
+
+	sh := &shard{namespace: namespace, localizers: make(map[string]*i18n.Localizer, len(c.AcceptLanguages)+1), memory: memory}
+	for _, tag := range c.AcceptLanguages {
+		sh.localizers[tag.String()] = i18n.NewLocalizer(bundle, tag.String())
+	}
+	if _, ok := sh.localizers[c.DefaultLanguage.String()]; !ok {
+		sh.localizers[c.DefaultLanguage.String()] = i18n.NewLocalizer(bundle, c.DefaultLanguage.String())
+	}
+	return sh, nil
+}
+
+// shard returns the shard of a namespace, loading it on first use.
+func (c *Config) shard(namespace string) (*shard, error) {
+	if sh, ok := c.shards.get(namespace); ok {
+		return sh, nil
+	}
+	sh, err := c.loadShard(namespace)
+	if err != nil {
+		return nil, err
+	}
+	return c.shards.add(sh), nil
+}
+
+// LoadNamespace loads the catalog shard of a namespace ahead of its first use.
+func (c *Config) LoadNamespace(namespace string) error {
+	c = c.active()
+	if !c.isNamespace(namespace) {
+		return fmt.Errorf("i18n.LoadNamespace error: unknown namespace %q", namespace)
+	}
+	if _, err := c.shard(namespace); err != nil {
+		return fmt.Errorf("i18n.LoadNamespace error: %v", err)
+	}
+	return nil
+}
+
+// EvictNamespace releases the catalog shard of a namespace. It is loaded
+// again on the next use of one of its messages.
+func (c *Config) EvictNamespace(namespace string) {
+	c.active().shards.remove(namespace)
+}
+
+// LoadedNamespaces returns the namespaces whose shards are in memory: the
+// CriticalShards first, then the others most recently used first.
+func (c *Config) LoadedNamespaces() []string {
+	shards := c.active().shards.all()
+	namespaces := make([]string, len(shards))
+	for i, sh := range shards {
+		namespaces[i] = sh.namespace
+	}
+	return namespaces
+}
+
+// localizer returns the localizer of the shard for lang.
+func (sh *shard) localizer(lang string) (*i18n.Localizer, bool) {
+	localizer, ok := sh.localizers[lang]
+	return localizer, ok
+}
+
+// languageLocalizer returns the localizer of the main catalog for lang.
+func (c *Config) languageLocalizer(lang string) (*i18n.Localizer, bool) {
+	localizer, ok := c.localizerMap.Load(lang)
+	if !ok {
+		return nil, false
+	}
+	return localizer.(*i18n.Localizer), true
+}
+
+// localizer returns the localizer of a message in lang and the localize
+// config to use with it. Messages with an ID of the form <namespace>.<id> are
+// localized from the namespace shard under their ID within the namespace.
+func (c *Config) localizer(lang string, lc *i18n.LocalizeConfig) (*i18n.Localizer, *i18n.LocalizeConfig, error) {
+	if namespace, id, ok := strings.Cut(lc.MessageID, "."); ok && c.isNamespace(namespace) {
+		sh, err := c.shard(namespace)
+		if err != nil {
+			return nil, nil, err
+		}
+		localizer, ok := sh.localizer(lang)
+		if !ok {
+			return nil, nil, fmt.Errorf("no localizer for language %q in namespace %q", lang, namespace)
+		}
+		sharded := *lc
+		sharded.MessageID = id
+		return localizer, &sharded, nil
+	}
+	localizer, ok := c.languageLocalizer(lang)
+	if !ok {
+		return nil, nil, fmt.Errorf("no localizer for language %q", lang)
+	}
+	return localizer, lc, nil
+}
@@ -0,0 +1,83 @@
+package echoi18n
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"sync/atomic"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// shardFiles are the message files of the namespace shard tests.
+var shardFiles = map[string]string{
+	"localize/en.yaml":          "welcome: hello",
+	"localize/zh.yaml":          "welcome: 你好",
+	"localize/checkout/en.yaml": "title: Checkout",
+	"localize/checkout/zh.yaml": "title: 结账",
+	"localize/account/en.yaml":  "title: Account",
+	"localize/search/en.yaml":   "title: Search",
+}
+
+// TestConfig_Namespaces tests loading and evicting namespace shards.
+func TestConfig_Namespaces(t *testing.T) {
+	t.Parallel()
+	var loads int32
+	cfg := &Config{
+		Loader: LoaderFunc(func(path string) ([]byte, error) {
+			atomic.AddInt32(&loads, 1)
+			if content, ok := shardFiles[path]; ok {
+				return []byte(content), nil
+			}
+			return nil, os.ErrNotExist
+		}),
+		RootPath:   "localize",
+		Namespaces: []string{"checkout", "account", "search"},
+		MaxShards:  2,
+	}
+	app := echo.New()
+	app.Use(NewMiddleware(cfg))
+	app.GET("/:id", func(c echo.Context) error {
+		return c.String(http.StatusOK, MustLocalize(c, c.Param("id")))
+	})
+
+	tests := []struct {
+		name string
+		lang language.Tag
+		url  string
+		want string
+	}{
+		{"main catalog", language.Chinese, "welcome", "你好"},
+		{"shard", language.Chinese, "checkout.title", "结账"},
+		{"shard default language", language.English, "account.title", "Account"},
+		{"shard fallback", language.Chinese, "account.title", "Account"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := makeRequest(tt.lang, tt.url, app)
+			assert.NoError(t, err)
+			body, _ := io.ReadAll(got.Body)
+			assert.Equal(t, tt.want, string(body))
+		})
+	}
+	assert.Equal(t, []string{"account", "checkout"}, cfg.LoadedNamespaces())
+
+	assert.NoError(t, cfg.LoadNamespace("search"))
+	assert.Equal(t, []string{"search", "account"}, cfg.LoadedNamespaces())
+	cfg.EvictNamespace("account")
+	assert.Equal(t, []string{"search"}, cfg.LoadedNamespaces())
+
+	before := atomic.LoadInt32(&loads)
+	assert.NoError(t, cfg.LoadNamespace("search"))
+	assert.Equal(t, before, atomic.LoadInt32(&loads))
+	assert.EqualError(t, cfg.LoadNamespace("billing"), `i18n.LoadNamespace error: unknown namespace "billing"`)
+
+	_, _, err := cfg.active().localizer("es", &i18n.LocalizeConfig{MessageID: "welcome"})
+	assert.EqualError(t, err, `no localizer for language "es"`)
+	_, _, err = cfg.active().localizer("es", &i18n.LocalizeConfig{MessageID: "search.title"})
+	assert.EqualError(t, err, `no localizer for language "es" in namespace "search"`)
+}
@@ -0,0 +1,92 @@
+package echoi18n
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// sitemapURLSet is the root element of a sitemap.xml document.
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	XHTML   string       `xml:"xmlns:xhtml,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+// sitemapURL is a single sitemap entry with its language alternates.
+type sitemapURL struct {
+	Loc   string        `xml:"loc"`
+	Links []sitemapLink `xml:"xhtml:link"`
+}
+
+// sitemapLink is an xhtml:link alternate of a sitemap entry.
+type sitemapLink struct {
+	Rel      string `xml:"rel,attr"`
+	HrefLang string `xml:"hreflang,attr"`
+	Href     string `xml:"href,attr"`
+}
+
+// LocalizedPath returns the route path prefixed for the given language.
+func (c *Config) LocalizedPath(lang, route string) string {
+	c = c.active()
+	if !strings.HasPrefix(route, "/") {
+		route = "/" + route
+	}
+	return c.PathPrefix(lang) + route
+}
+
+// Sitemap generates a sitemap.xml document with an entry for every supported
+// language of each route, linked together with xhtml:link alternates.
+func (c *Config) Sitemap(baseURL string, routes ...string) ([]byte, error) {
+	c = c.active()
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	urlSet := sitemapURLSet{
+		Xmlns: "http://www.sitemaps.org/schemas/sitemap/0.9",
+		XHTML: "http://www.w3.org/1999/xhtml",
+	}
+
+	for _, route := range routes {
+		links := make([]sitemapLink, 0, len(c.AcceptLanguages)+1)
+		for _, lang := range c.AcceptLanguages {
+			links = append(links, sitemapLink{
+				Rel:      "alternate",
+				HrefLang: lang.String(),
+				Href:     baseURL + c.LocalizedPath(lang.String(), route),
+			})
+		}
+		links = append(links, sitemapLink{
+			Rel:      "alternate",
+			HrefLang: "x-default",
+			Href:     baseURL + c.LocalizedPath(c.DefaultLanguage.String(), route),
+		})
+
+		for _, link := range links[:len(links)-1] {
+			urlSet.URLs = append(urlSet.URLs, sitemapURL{Loc: link.Href, Links: links})
+		}
+	}
+
+	buf, err := xml.MarshalIndent(urlSet, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("i18n.Sitemap error: %v", err)
+	}
+	return append([]byte(xml.Header), buf...), nil
+}
+
+// SitemapHandler returns a handler serving the sitemap of the given routes.
+func SitemapHandler(baseURL string, routes ...string) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		appCfg, err := getConfig(c)
+		if err != nil {
+			return fmt.Errorf("i18n.SitemapHandler error: %v", err)
+		}
+		buf, err := appCfg.Sitemap(baseURL, routes...)
+		if err != nil {
+			return err
+		}
+		return c.Blob(http.StatusOK, echo.MIMEApplicationXMLCharsetUTF8, buf)
+	}
+}
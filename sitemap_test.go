@@ -0,0 +1,44 @@
+package echoi18n
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// TestSitemapHandler tests the generated sitemap alternates.
+func TestSitemapHandler(t *testing.T) {
+	t.Parallel()
+	app := echo.New()
+	app.Use(NewMiddleware(&Config{}))
+	app.GET("/sitemap.xml", SitemapHandler("https://example.com/", "/products"))
+
+	got, err := makeRequest(language.Und, "sitemap.xml", app)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, got.StatusCode)
+	body, _ := io.ReadAll(got.Body)
+
+	links := `
+    <xhtml:link rel="alternate" hreflang="zh" href="https://example.com/zh/products"></xhtml:link>
+    <xhtml:link rel="alternate" hreflang="en" href="https://example.com/en/products"></xhtml:link>
+    <xhtml:link rel="alternate" hreflang="x-default" href="https://example.com/en/products"></xhtml:link>
+  </url>`
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9" xmlns:xhtml="http://www.w3.org/1999/xhtml">
+  <url>
+    <loc>https://example.com/zh/products</loc>`+links+`
+  <url>
+    <loc>https://example.com/en/products</loc>`+links+`
+</urlset>`, string(body))
+}
+
+// TestConfig_LocalizedPath tests prefixing routes with the language.
+func TestConfig_LocalizedPath(t *testing.T) {
+	cfg := configDefault(&Config{PathPrefix: func(lang string) string { return "/site/" + lang }})
+	assert.Equal(t, "/site/zh/about", cfg.LocalizedPath("zh", "about"))
+	assert.Equal(t, "/en/", configDefault(&Config{}).LocalizedPath("en", "/"))
+}
@@ -0,0 +1,91 @@
+package echoi18n
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/labstack/echo/v4"
+	"golang.org/x/text/language"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// transliterations are the language-specific transliteration rules of
+// lowercase letters.
+var transliterations = map[string]map[rune]string{
+	"de": {'ä': "ae", 'ö': "oe", 'ü': "ue"},
+	"da": {'å': "aa", 'ø': "oe"},
+	"nb": {'å': "aa", 'ø': "oe"},
+	"ru": cyrillicTransliteration,
+	"uk": cyrillicTransliteration,
+	"bg": cyrillicTransliteration,
+}
+
+// cyrillicTransliteration is a simplified Cyrillic to Latin transliteration.
+var cyrillicTransliteration = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "yo", 'ж': "zh",
+	'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts",
+	'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu",
+	'я': "ya", 'і': "i", 'ї': "yi", 'є': "ye", 'ґ': "g",
+}
+
+// latinTransliteration covers Latin letters without a decomposition.
+var latinTransliteration = map[rune]string{
+	'ß': "ss", 'æ': "ae", 'œ': "oe", 'ø': "o", 'đ': "d", 'ł': "l", 'þ': "th", 'ı': "i",
+}
+
+// Slugify converts s to a lowercase, hyphen-separated slug for URLs and file
+// names, transliterated for the negotiated language (ü → ue in German).
+// Config.Transliterate can provide further rules, e.g. pinyin for Chinese.
+// Letters of other scripts are kept as they are.
+func Slugify(c echo.Context, s string) string {
+	lang := language.Und
+	var transliterate func(string, string) string
+	if appCfg, err := getConfig(c); err == nil {
+		lang = language.Make(appCfg.language(c))
+		transliterate = appCfg.Transliterate
+	}
+	if transliterate != nil {
+		s = transliterate(lang.String(), s)
+	}
+	base, _ := lang.Base()
+	return slugify(s, transliterations[strings.ToLower(base.String())])
+}
+
+// slugify transliterates s with the given rules and builds the slug. s is
+// NFC-normalized first, so decomposed letters match the rules.
+func slugify(s string, rules map[rune]string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(norm.NFC.String(s)) {
+		if t, ok := rules[r]; ok {
+			b.WriteString(t)
+		} else if t, ok := latinTransliteration[r]; ok {
+			b.WriteString(t)
+		} else {
+			b.WriteRune(r)
+		}
+	}
+
+	stripMarks := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	stripped, _, err := transform.String(stripMarks, b.String())
+	if err != nil {
+		stripped = b.String()
+	}
+
+	var slug strings.Builder
+	hyphen := false
+	for _, r := range stripped {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if hyphen && slug.Len() > 0 {
+				slug.WriteByte('-')
+			}
+			slug.WriteRune(r)
+			hyphen = false
+		} else {
+			hyphen = true
+		}
+	}
+	return slug.String()
+}
@@ -0,0 +1,73 @@
+package echoi18n
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// Test_slugify tests transliteration and slug building.
+func Test_slugify(t *testing.T) {
+	tests := []struct {
+		name  string
+		s     string
+		rules map[rune]string
+		want  string
+	}{
+		{"plain", "  Hello, World!  ", nil, "hello-world"},
+		{"german", "Grüße aus München", transliterations["de"], "gruesse-aus-muenchen"},
+		{"german decomposed", "Gru\u0308\u00dfe aus Mu\u0308nchen", transliterations["de"], "gruesse-aus-muenchen"},
+		{"russian decomposed", "\u0415\u0308лка", transliterations["ru"], "yolka"},
+		{"diacritics", "Crème Brûlée à Łódź", nil, "creme-brulee-a-lodz"},
+		{"russian", "Привет мир", transliterations["ru"], "privet-mir"},
+		{"other scripts", "你好 世界", nil, "你好-世界"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, slugify(tt.s, tt.rules))
+		})
+	}
+}
+
+// TestSlugify tests slugs for the negotiated language.
+func TestSlugify(t *testing.T) {
+	t.Parallel()
+	app := echo.New()
+	app.Use(NewMiddleware(&Config{
+		AcceptLanguages: []language.Tag{language.German, language.Chinese},
+		DefaultLanguage: language.German,
+		Loader: LoaderFunc(func(path string) ([]byte, error) {
+			return nil, nil
+		}),
+		Transliterate: func(lang, s string) string {
+			if lang == "zh" {
+				return strings.ReplaceAll(s, "你好", "ni hao")
+			}
+			return s
+		},
+	}))
+	app.GET("/", func(c echo.Context) error {
+		return c.String(http.StatusOK, Slugify(c, c.QueryParam("s")))
+	})
+
+	tests := []struct {
+		lang language.Tag
+		s    string
+		want string
+	}{
+		{language.German, "%C3%9Cber%20uns", "ueber-uns"},
+		{language.German, "U%CC%88ber%20uns", "ueber-uns"},
+		{language.Chinese, "%E4%BD%A0%E5%A5%BD", "ni-hao"},
+	}
+	for _, tt := range tests {
+		got, err := makeRequest(tt.lang, "?s="+tt.s, app)
+		assert.NoError(t, err)
+		body, _ := io.ReadAll(got.Body)
+		assert.Equal(t, tt.want, string(body))
+	}
+}
@@ -0,0 +1,172 @@
+package echoi18n
+
+import (
+	"fmt"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// Concurrency model
+//
+// The loaded catalog is published as a snapshot: a Config sharing the
+// settings of the one passed to NewMiddleware, holding the messages,
+// localizers and caches built from them. A published snapshot is never
+// modified. Reload and SetState build a new snapshot, copying what they
+// change, and swap it in atomically, one update at a time.
+//
+// The middleware pins the current snapshot in the Echo Context at the start
+// of each request, so every Localize call of a request sees the same catalog
+// even while an update is in flight. The methods of the Config passed to
+// NewMiddleware read the current snapshot.
+
+// root returns the Config passed to NewMiddleware that publishes the snapshots.
+func (c *Config) root() *Config {
+	if c.origin != nil {
+		return c.origin
+	}
+	return c
+}
+
+// active returns the current snapshot of the Config passed to NewMiddleware,
+// or the snapshot itself. Configs not served by a middleware yet are their
+// own snapshot.
+func (c *Config) active() *Config {
+	if c.origin != nil {
+		return c
+	}
+	if snapshot := c.current.Load(); snapshot != nil {
+		return snapshot
+	}
+	return c
+}
+
+// newSnapshot returns an empty snapshot with the settings of the middleware.
+func (c *Config) newSnapshot() *Config {
+	root := c.root()
+	snapshot := root.settings.Clone()
+	snapshot.origin, snapshot.parser = root, root.parser.forSnapshot()
+	return snapshot
+}
+
+// derive returns a new snapshot sharing the catalog of the snapshot, for
+// updates that replace part of it.
+func (c *Config) derive() *Config {
+	snapshot := c.newSnapshot()
+	snapshot.parser = c.parser
+	snapshot.bundle = c.bundle
+	snapshot.localizerMap = c.localizerMap
+	snapshot.fallbacks = c.fallbacks
+	snapshot.chains = c.chains
+	snapshot.accept = c.accept
+	snapshot.publicAccept = c.publicAccept
+	snapshot.beta = c.beta
+	snapshot.prefixes = c.prefixes
+	snapshot.messages = c.messages
+	snapshot.index = c.index
+	snapshot.metadata = c.metadata
+	snapshot.raw = c.raw
+	snapshot.uncacheable = c.uncacheable
+	snapshot.variants = c.variants
+	snapshot.version = c.version
+	snapshot.diagnostics = c.diagnostics
+	snapshot.shards = c.shards
+	snapshot.glossaries = c.glossaries
+	snapshot.background = c.background
+	snapshot.renders = c.renders
+	return snapshot
+}
+
+// build loads the catalog of an empty snapshot, returning the first load error.
+func (c *Config) build() error {
+	c.bundle = i18n.NewBundle(c.DefaultLanguage)
+	for format, unmarshalFunc := range c.unmarshalFuncs() {
+		c.bundle.RegisterUnmarshalFunc(format, unmarshalFunc)
+	}
+	c.shards = newShardCache(c.MaxShards, c.CriticalShards)
+	c.glossaries = newGlossaryCache(c.GlossaryCache)
+	if c.Cache != nil {
+		c.renders = c.Cache
+	} else if c.RenderCache > 0 {
+		c.renders = newRenderCache(c.RenderCache, c.now)
+	}
+
+	c.diagnostics = append(c.discoverLanguages(), c.load()...)
+	if err := c.diagnostics.Err(); err != nil {
+		return err
+	}
+	c.initIndex()
+	c.initRawMessages()
+	c.initUncacheable()
+	c.initVariants()
+	c.version = catalogVersion(c.messages)
+	c.applyStates(c.root().states)
+	c.initLocalizerMap()
+	c.initFallbacks()
+	c.accept = newAcceptMatcher(c.AcceptLanguages, c.PreferredScripts)
+	c.initBeta()
+	c.initPrefixes()
+	c.diagnostics = append(c.diagnostics, c.loadCritical()...)
+	if err := c.diagnostics.Err(); err != nil {
+		return err
+	}
+	if c.WarmUp {
+		c.diagnostics = append(c.diagnostics, c.warmUp()...)
+	}
+	c.background = make(chan struct{})
+	if c.BackgroundLoad {
+		go c.loadBackground(c.background)
+	} else {
+		close(c.background)
+	}
+	return nil
+}
+
+// Reload loads the message files again and publishes them as a new snapshot.
+// Requests in flight finish with the catalog they started with. On error, the
+// current catalog stays in service. A new catalog version is published to the
+// peers of the Coordinator; if that fails, the catalog stays applied locally.
+func (c *Config) Reload() error {
+	changed, err := c.reload(false)
+	if err != nil {
+		return fmt.Errorf("i18n.Reload error: %v", err)
+	}
+	if err := c.publish(changed); err != nil {
+		return fmt.Errorf("i18n.Reload error: %v", err)
+	}
+	return nil
+}
+
+// publish notifies the peers of the Coordinator of a changed catalog version.
+func (c *Config) publish(changed bool) error {
+	if coordinator := c.root().settings.Coordinator; coordinator != nil && changed {
+		return coordinator.Publish(c.Version())
+	}
+	return nil
+}
+
+// reload builds a new snapshot from the message files and publishes it,
+// reporting whether the catalog version changed. While a catalog is staged
+// by Schedule, the snapshot replaces it instead and nothing is published.
+// With onlyChanged, for the polls of ReloadInterval and RefreshInterval, only
+// the files due are read and a catalog of the current version is not
+// published, keeping the current caches.
+func (c *Config) reload(onlyChanged bool) (bool, error) {
+	root := c.root()
+	root.mu.Lock()
+	defer root.mu.Unlock()
+	if !onlyChanged {
+		root.expireLoader()
+	}
+	snapshot := root.newSnapshot()
+	if err := snapshot.build(); err != nil {
+		return false, err
+	}
+	if root.restage(snapshot) {
+		return false, nil
+	}
+	changed := snapshot.version != root.active().version
+	if changed || !onlyChanged {
+		root.store(snapshot)
+	}
+	return changed, nil
+}
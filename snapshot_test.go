@@ -0,0 +1,140 @@
+package echoi18n
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// reloadLoader serves a message catalog that tests can replace.
+type reloadLoader struct {
+	mu      sync.Mutex
+	content string // Content of localize/en.yaml.
+	err     error  // Loading error, if any.
+}
+
+// set replaces the catalog.
+func (l *reloadLoader) set(content string, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.content, l.err = content, err
+}
+
+// LoadMessage returns the current catalog.
+func (l *reloadLoader) LoadMessage(path string) ([]byte, error) {
+	if path != "localize/en.yaml" {
+		return nil, os.ErrNotExist
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return []byte(l.content), l.err
+}
+
+// newReloadServer creates an Echo server localizing "welcome" twice per
+// request, calling pause between both.
+func newReloadServer(loader *reloadLoader, pause func()) (*Config, *echo.Echo) {
+	cfg := &Config{AcceptLanguages: []language.Tag{language.English}, Loader: loader, RootPath: "localize"}
+	app := echo.New()
+	app.Use(NewMiddleware(cfg))
+	app.GET("/", func(c echo.Context) error {
+		first := MustLocalize(c, "welcome")
+		pause()
+		return c.String(http.StatusOK, first+" "+MustLocalize(c, "welcome"))
+	})
+	return cfg, app
+}
+
+// TestConfig_Reload tests publishing a reloaded catalog to new requests only.
+func TestConfig_Reload(t *testing.T) {
+	t.Parallel()
+	loader := &reloadLoader{}
+	loader.set("welcome: v1", nil)
+	started, resume := make(chan struct{}), make(chan struct{})
+	var pausing atomic.Bool
+	cfg, app := newReloadServer(loader, func() {
+		if pausing.Load() {
+			close(started)
+			<-resume
+		}
+	})
+	version := cfg.Version()
+
+	pausing.Store(true)
+	inFlight := make(chan string)
+	go func() {
+		got, _ := makeRequest(language.English, "", app)
+		body, _ := io.ReadAll(got.Body)
+		inFlight <- string(body)
+	}()
+	<-started
+	pausing.Store(false)
+	loader.set("welcome: v2", nil)
+	assert.NoError(t, cfg.Reload())
+	close(resume)
+	assert.Equal(t, "v1 v1", <-inFlight)
+
+	got, err := makeRequest(language.English, "", app)
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(got.Body)
+	assert.Equal(t, "v2 v2", string(body))
+	assert.NotEqual(t, version, cfg.Version())
+
+	loader.set("", errors.New("unavailable"))
+	assert.EqualError(t, cfg.Reload(), "i18n.Reload error: unavailable")
+	got, err = makeRequest(language.English, "", app)
+	assert.NoError(t, err)
+	body, _ = io.ReadAll(got.Body)
+	assert.Equal(t, "v2 v2", string(body))
+}
+
+// TestConfig_Reload_concurrent tests that requests never see a half-updated
+// catalog while reloads and workflow edits are in flight.
+func TestConfig_Reload_concurrent(t *testing.T) {
+	t.Parallel()
+	loader := &reloadLoader{}
+	loader.set("welcome: v0", nil)
+	cfg, app := newReloadServer(loader, func() {})
+
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for _, catalog := range []string{"welcome: v1", "welcome: v2", "welcome: v3"} {
+			loader.set(catalog, nil)
+			assert.NoError(t, cfg.Reload())
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for _, state := range []string{StateDraft, StateReviewed, StateApproved} {
+			assert.NoError(t, cfg.SetState("en", "welcome", state))
+		}
+	}()
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	for running := true; running; {
+		select {
+		case <-done:
+			running = false
+		default:
+		}
+		got, err := makeRequest(language.English, "", app)
+		assert.NoError(t, err)
+		body, _ := io.ReadAll(got.Body)
+		assert.Regexp(t, `^(v\d) (v\d)$`, string(body))
+		assert.Equal(t, string(body[:2]), string(body[3:]))
+	}
+	assert.Equal(t, "welcome: v3", loader.content)
+}
@@ -0,0 +1,241 @@
+package echoi18n
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"golang.org/x/text/language"
+)
+
+// NumberSpeller spells out an integer in words for a language, e.g. an
+// implementation backed by the complete CLDR rule-based number formats.
+type NumberSpeller func(n int64, lang language.Tag) (string, error)
+
+// spellOutRules are the built-in cardinal spell-out rules of a language.
+type spellOutRules struct {
+	minus string              // Prefix of negative numbers.
+	spell func(uint64) string // Spells out a non-negative number.
+}
+
+// spellOutLanguages are the built-in rules keyed by base language.
+var spellOutLanguages = map[string]spellOutRules{
+	"en": {minus: "minus ", spell: spellOutEnglish},
+	"fr": {minus: "moins ", spell: spellOutFrench},
+	"zh": {minus: "负", spell: spellOutChinese},
+}
+
+var (
+	englishUnits = []string{
+		"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine", "ten",
+		"eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen", "nineteen",
+	}
+	englishTens   = []string{"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety"}
+	englishScales = []string{"", "thousand", "million", "billion", "trillion", "quadrillion", "quintillion"}
+
+	frenchUnits = []string{
+		"zéro", "un", "deux", "trois", "quatre", "cinq", "six", "sept", "huit", "neuf", "dix",
+		"onze", "douze", "treize", "quatorze", "quinze", "seize",
+	}
+	frenchTens   = []string{"", "", "vingt", "trente", "quarante", "cinquante", "soixante"}
+	frenchScales = []string{"", "mille", "million", "milliard", "billion", "billiard", "trillion"}
+
+	chineseDigits = []string{"零", "一", "二", "三", "四", "五", "六", "七", "八", "九"}
+	chineseUnits  = []string{"", "十", "百", "千"}
+	chineseGroups = []string{"", "万", "亿", "万亿", "亿亿"}
+)
+
+// numberGroups splits n into groups of digits of the given size, lowest first.
+func numberGroups(n, size uint64) []uint64 {
+	groups := []uint64{n % size}
+	for n /= size; n > 0; n /= size {
+		groups = append(groups, n%size)
+	}
+	return groups
+}
+
+// spellOutEnglish spells out n in English, e.g. "one hundred forty-two".
+func spellOutEnglish(n uint64) string {
+	if n == 0 {
+		return englishUnits[0]
+	}
+	below100 := func(n uint64) string {
+		if n < 20 {
+			return englishUnits[n]
+		}
+		if n%10 == 0 {
+			return englishTens[n/10]
+		}
+		return englishTens[n/10] + "-" + englishUnits[n%10]
+	}
+
+	var words []string
+	groups := numberGroups(n, 1000)
+	for i := len(groups) - 1; i >= 0; i-- {
+		g := groups[i]
+		if g == 0 {
+			continue
+		}
+		if g >= 100 {
+			words = append(words, englishUnits[g/100], "hundred")
+		}
+		if g%100 != 0 {
+			words = append(words, below100(g%100))
+		}
+		if englishScales[i] != "" {
+			words = append(words, englishScales[i])
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// frenchBelow100 spells out n < 100 in French. Final is false when the number
+// multiplies a following "mille", which drops the plural of "quatre-vingts".
+func frenchBelow100(n uint64, final bool) string {
+	switch t, u := n/10, n%10; {
+	case n <= 16:
+		return frenchUnits[n]
+	case n < 20:
+		return "dix-" + frenchUnits[u]
+	case t <= 6 && u == 0:
+		return frenchTens[t]
+	case t <= 6 && u == 1:
+		return frenchTens[t] + "-et-un"
+	case t <= 6:
+		return frenchTens[t] + "-" + frenchUnits[u]
+	case t == 7 && u == 1:
+		return "soixante-et-onze"
+	case t == 7:
+		return "soixante-" + frenchBelow100(10+u, final)
+	case t == 8 && u == 0 && final:
+		return "quatre-vingts"
+	case t == 8 && u == 0:
+		return "quatre-vingt"
+	case t == 8:
+		return "quatre-vingt-" + frenchUnits[u]
+	default:
+		return "quatre-vingt-" + frenchBelow100(10+u, final)
+	}
+}
+
+// frenchBelow1000 spells out n < 1000 in French, e.g. "deux cent un".
+func frenchBelow1000(n uint64, final bool) string {
+	h, r := n/100, n%100
+	var words []string
+	switch {
+	case h == 1:
+		words = append(words, "cent")
+	case h > 1 && r == 0 && final:
+		words = append(words, frenchUnits[h], "cents")
+	case h > 1:
+		words = append(words, frenchUnits[h], "cent")
+	}
+	if r != 0 || h == 0 {
+		words = append(words, frenchBelow100(r, final))
+	}
+	return strings.Join(words, " ")
+}
+
+// spellOutFrench spells out n in French, e.g. "quatre-vingt-dix-sept".
+func spellOutFrench(n uint64) string {
+	if n == 0 {
+		return frenchUnits[0]
+	}
+	var words []string
+	groups := numberGroups(n, 1000)
+	for i := len(groups) - 1; i >= 0; i-- {
+		g := groups[i]
+		switch {
+		case g == 0:
+		case i == 0:
+			words = append(words, frenchBelow1000(g, true))
+		case i == 1 && g == 1:
+			words = append(words, "mille")
+		case i == 1:
+			words = append(words, frenchBelow1000(g, false), "mille")
+		case g == 1:
+			words = append(words, "un", frenchScales[i])
+		default:
+			words = append(words, frenchBelow1000(g, true), frenchScales[i]+"s")
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// chineseBelow10000 spells out n < 10000 in Chinese, e.g. "一千零二".
+func chineseBelow10000(n uint64) string {
+	var b strings.Builder
+	zero := false
+	for p := 3; p >= 0; p-- {
+		d := n
+		for i := 0; i < p; i++ {
+			d /= 10
+		}
+		d %= 10
+		if d == 0 {
+			zero = b.Len() > 0
+			continue
+		}
+		if zero {
+			b.WriteString(chineseDigits[0])
+			zero = false
+		}
+		b.WriteString(chineseDigits[d] + chineseUnits[p])
+	}
+	return b.String()
+}
+
+// spellOutChinese spells out n in Chinese, e.g. "四十二" or "一万零一".
+func spellOutChinese(n uint64) string {
+	if n == 0 {
+		return chineseDigits[0]
+	}
+	var b strings.Builder
+	zero := false
+	groups := numberGroups(n, 10000)
+	for i := len(groups) - 1; i >= 0; i-- {
+		g := groups[i]
+		if g == 0 {
+			zero = b.Len() > 0
+			continue
+		}
+		if b.Len() > 0 && (zero || g < 1000) {
+			b.WriteString(chineseDigits[0])
+		}
+		b.WriteString(chineseBelow10000(g) + chineseGroups[i])
+		zero = false
+	}
+	s := b.String()
+	if strings.HasPrefix(s, "一十") {
+		s = strings.TrimPrefix(s, "一")
+	}
+	return s
+}
+
+// defaultSpellOut spells out n with the built-in English, French and Chinese rules.
+func defaultSpellOut(n int64, lang language.Tag) (string, error) {
+	base, _ := lang.Base()
+	rules, ok := spellOutLanguages[base.String()]
+	if !ok {
+		return "", fmt.Errorf("spell-out not supported for language %q", lang)
+	}
+	if n < 0 {
+		return rules.minus + rules.spell(uint64(-(n+1))+1), nil
+	}
+	return rules.spell(uint64(n)), nil
+}
+
+// SpellOut spells out n in words in the negotiated language, e.g. "forty-two",
+// "quarante-deux" or "四十二". The built-in rules cover English, French and
+// Chinese; other languages need a Config.NumberSpeller backend.
+func SpellOut(c echo.Context, n int64) (string, error) {
+	appCfg, err := getConfig(c)
+	if err != nil {
+		return "", fmt.Errorf("i18n.SpellOut error: %v", err)
+	}
+	words, err := appCfg.NumberSpeller(n, language.Make(appCfg.language(c)))
+	if err != nil {
+		return "", fmt.Errorf("i18n.SpellOut error: %v", err)
+	}
+	return words, nil
+}
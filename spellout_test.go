@@ -0,0 +1,74 @@
+package echoi18n
+
+import (
+	"io"
+	"math"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// Test_defaultSpellOut tests the built-in spell-out rules.
+func Test_defaultSpellOut(t *testing.T) {
+	tests := []struct {
+		n    int64
+		lang language.Tag
+		want string
+	}{
+		{0, language.English, "zero"},
+		{42, language.English, "forty-two"},
+		{1999, language.English, "one thousand nine hundred ninety-nine"},
+		{-7, language.English, "minus seven"},
+		{2000001, language.English, "two million one"},
+		{math.MinInt64, language.English, "minus nine quintillion two hundred twenty-three quadrillion three hundred seventy-two trillion thirty-six billion eight hundred fifty-four million seven hundred seventy-five thousand eight hundred eight"},
+		{42, language.French, "quarante-deux"},
+		{21, language.French, "vingt-et-un"},
+		{71, language.French, "soixante-et-onze"},
+		{80, language.French, "quatre-vingts"},
+		{97, language.French, "quatre-vingt-dix-sept"},
+		{200, language.French, "deux cents"},
+		{201, language.French, "deux cent un"},
+		{80000, language.French, "quatre-vingt mille"},
+		{1001, language.French, "mille un"},
+		{2000000, language.French, "deux millions"},
+		{42, language.Chinese, "四十二"},
+		{10, language.Chinese, "十"},
+		{1002, language.Chinese, "一千零二"},
+		{10001, language.Chinese, "一万零一"},
+		{100000, language.Chinese, "十万"},
+		{120000000, language.Chinese, "一亿二千万"},
+		{-5, language.Chinese, "负五"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			got, err := defaultSpellOut(tt.n, tt.lang)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := defaultSpellOut(1, language.Korean)
+	assert.EqualError(t, err, `spell-out not supported for language "ko"`)
+}
+
+// TestSpellOut tests spelling out numbers in the negotiated language.
+func TestSpellOut(t *testing.T) {
+	t.Parallel()
+	app := echo.New()
+	app.Use(NewMiddleware(&Config{}))
+	app.GET("/", func(c echo.Context) error {
+		words, err := SpellOut(c, 42)
+		if err != nil {
+			return err
+		}
+		return c.String(http.StatusOK, words)
+	})
+
+	got, err := makeRequest(language.Chinese, "", app)
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(got.Body)
+	assert.Equal(t, "四十二", string(body))
+}
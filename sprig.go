@@ -0,0 +1,102 @@
+package echoi18n
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+	"unicode/utf8"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+// sprigFuncs is a curated subset of the Sprig template functions, limited to
+// side-effect free string helpers with bounded output. Signatures follow Sprig.
+var sprigFuncs = template.FuncMap{
+	"default":    sprigDefault,
+	"empty":      isEmpty,
+	"coalesce":   coalesce,
+	"trunc":      trunc,
+	"abbrev":     abbrev,
+	"upper":      strings.ToUpper,
+	"lower":      strings.ToLower,
+	"title":      title,
+	"trim":       strings.TrimSpace,
+	"trimPrefix": func(prefix, s string) string { return strings.TrimPrefix(s, prefix) },
+	"trimSuffix": func(suffix, s string) string { return strings.TrimSuffix(s, suffix) },
+	"replace":    func(old, new, s string) string { return strings.ReplaceAll(s, old, new) },
+	"contains":   func(substr, s string) bool { return strings.Contains(s, substr) },
+	"hasPrefix":  func(prefix, s string) bool { return strings.HasPrefix(s, prefix) },
+	"hasSuffix":  func(suffix, s string) bool { return strings.HasSuffix(s, suffix) },
+	"quote":      func(v interface{}) string { return fmt.Sprintf("%q", fmt.Sprint(v)) },
+	"squote":     func(v interface{}) string { return "'" + fmt.Sprint(v) + "'" },
+	"pluralize":  pluralize,
+}
+
+// title title-cases s. Casers keep state between calls, so each call uses
+// its own.
+func title(s string) string {
+	return cases.Title(language.Und).String(s)
+}
+
+// isEmpty reports whether the value is nil or the zero value of its type.
+func isEmpty(v interface{}) bool {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() {
+		return true
+	}
+	switch rv.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return rv.Len() == 0
+	default:
+		return rv.IsZero()
+	}
+}
+
+// sprigDefault returns v, or d if v is empty.
+func sprigDefault(d interface{}, v ...interface{}) interface{} {
+	if len(v) == 0 || isEmpty(v[0]) {
+		return d
+	}
+	return v[0]
+}
+
+// coalesce returns the first non-empty value.
+func coalesce(v ...interface{}) interface{} {
+	for _, value := range v {
+		if !isEmpty(value) {
+			return value
+		}
+	}
+	return nil
+}
+
+// trunc truncates s to n runes, or keeps the last -n runes if n is negative.
+func trunc(n int, s string) string {
+	runes := []rune(s)
+	switch {
+	case n >= 0 && len(runes) > n:
+		return string(runes[:n])
+	case n < 0 && len(runes) > -n:
+		return string(runes[len(runes)+n:])
+	}
+	return s
+}
+
+// abbrev truncates s to width runes including a trailing ellipsis.
+func abbrev(width int, s string) string {
+	if width < 4 || utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	return trunc(width-3, s) + "..."
+}
+
+// pluralize returns singular if count is 1, plural otherwise. Prefer CLDR
+// plural forms in the message itself; this is a convenience for simple copy.
+func pluralize(count interface{}, singular, plural string) string {
+	if fmt.Sprint(count) == "1" {
+		return singular
+	}
+	return plural
+}
@@ -0,0 +1,79 @@
+package echoi18n
+
+import (
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// TestConfig_SprigFuncs tests the Sprig subset in message templates.
+func TestConfig_SprigFuncs(t *testing.T) {
+	t.Parallel()
+	app := echo.New()
+	app.Use(NewMiddleware(&Config{
+		SprigFuncs: true,
+		Bundles: []Bundle{{"en": {
+			{ID: "greeting", Other: `hello {{ default "guest" .name }}`},
+			{ID: "preview", Other: `{{ abbrev 10 .text }}`},
+			{ID: "items", Other: `{{ .count }} {{ pluralize .count "item" "items" }}`},
+		}}},
+	}))
+	app.GET("/", func(c echo.Context) error {
+		var lines string
+		for _, lc := range []*i18n.LocalizeConfig{
+			{MessageID: "greeting", TemplateData: map[string]string{}},
+			{MessageID: "greeting", TemplateData: map[string]string{"name": "alex"}},
+			{MessageID: "preview", TemplateData: map[string]string{"text": "a very long sentence"}},
+			{MessageID: "items", TemplateData: map[string]int{"count": 1}},
+			{MessageID: "items", TemplateData: map[string]int{"count": 3}},
+		} {
+			lines += MustLocalize(c, lc) + "\n"
+		}
+		return c.String(http.StatusOK, lines)
+	})
+
+	got, err := makeRequest(language.English, "", app)
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(got.Body)
+	assert.Equal(t, "hello guest\nhello alex\na very ...\n1 item\n3 items\n", string(body))
+}
+
+// TestConfig_SprigFuncs_concurrent tests rendering the stateful Sprig
+// functions from concurrent requests.
+func TestConfig_SprigFuncs_concurrent(t *testing.T) {
+	t.Parallel()
+	app := echo.New()
+	app.Use(NewMiddleware(&Config{
+		SprigFuncs: true,
+		Bundles:    []Bundle{{"en": {{ID: "heading", Other: `{{ title .text }}`}}}},
+	}))
+	app.GET("/", func(c echo.Context) error {
+		return c.String(http.StatusOK, MustLocalize(c, &i18n.LocalizeConfig{MessageID: "heading", TemplateData: map[string]string{"text": c.QueryParam("text")}}))
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got, err := makeRequest(language.English, "?text=hello+world", app)
+			assert.NoError(t, err)
+			body, _ := io.ReadAll(got.Body)
+			assert.Equal(t, "Hello World", string(body))
+		}()
+	}
+	wg.Wait()
+}
+
+// Test_trunc tests truncating strings from both ends.
+func Test_trunc(t *testing.T) {
+	assert.Equal(t, "你好", trunc(2, "你好世界"))
+	assert.Equal(t, "世界", trunc(-2, "你好世界"))
+	assert.Equal(t, "abc", trunc(5, "abc"))
+}
@@ -0,0 +1,65 @@
+package echoi18n
+
+import (
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+)
+
+// StaleLoader wraps a remote Loader to keep serving the last good copy of the
+// message files when its backend is unreachable, at startup or on Reload.
+// Every file loaded successfully is persisted to Dir.
+type StaleLoader struct {
+	Loader  Loader                       // Remote backend.
+	Dir     string                       // Directory of the last good copies.
+	OnStale func(path string, err error) // Reports a stale copy served because of the backend error, optional.
+}
+
+// cachePath returns the file of Dir holding the copy of a message file.
+func cachePath(dir, path string) string {
+	return filepath.Join(dir, url.PathEscape(path))
+}
+
+// writeCache atomically replaces the copy of a message file in dir.
+func writeCache(dir, path string, buf []byte) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(buf); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), cachePath(dir, path))
+}
+
+// LoadMessage loads a message file from the backend and persists it, or
+// returns its last good copy if the backend fails. Files missing from the
+// backend are reported missing.
+func (l *StaleLoader) LoadMessage(path string) ([]byte, error) {
+	buf, err := l.Loader.LoadMessage(path)
+	if err == nil {
+		// A copy that cannot be persisted only matters on a later outage.
+		_ = writeCache(l.Dir, path, buf)
+		return buf, nil
+	}
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	stale, cacheErr := os.ReadFile(cachePath(l.Dir, path))
+	if cacheErr != nil {
+		return nil, err
+	}
+	if l.OnStale != nil {
+		l.OnStale(path, err)
+	}
+	return stale, nil
+}
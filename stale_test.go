@@ -0,0 +1,68 @@
+package echoi18n
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// TestStaleLoader tests serving the last good copy while the backend is down.
+func TestStaleLoader(t *testing.T) {
+	t.Parallel()
+	var down bool
+	files := map[string]string{"localize/en.yaml": "welcome: hello"}
+	var stale []string
+	loader := &StaleLoader{
+		Loader: LoaderFunc(func(path string) ([]byte, error) {
+			if down {
+				return nil, errors.New("connection refused")
+			}
+			if content, ok := files[path]; ok {
+				return []byte(content), nil
+			}
+			return nil, os.ErrNotExist
+		}),
+		Dir:     t.TempDir(),
+		OnStale: func(path string, err error) { stale = append(stale, path+": "+err.Error()) },
+	}
+
+	buf, err := loader.LoadMessage("localize/en.yaml")
+	assert.NoError(t, err)
+	assert.Equal(t, "welcome: hello", string(buf))
+	_, err = loader.LoadMessage("localize/fr.yaml")
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	down = true
+	buf, err = loader.LoadMessage("localize/en.yaml")
+	assert.NoError(t, err)
+	assert.Equal(t, "welcome: hello", string(buf))
+	assert.Equal(t, []string{"localize/en.yaml: connection refused"}, stale)
+	_, err = loader.LoadMessage("localize/zh.yaml")
+	assert.EqualError(t, err, "connection refused")
+
+	down = false
+	files["localize/en.yaml"] = "welcome: hi"
+	buf, err = loader.LoadMessage("localize/en.yaml")
+	assert.NoError(t, err)
+	assert.Equal(t, "welcome: hi", string(buf))
+}
+
+// TestStaleLoader_startup tests starting the middleware from the last good copies.
+func TestStaleLoader_startup(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	assert.NoError(t, writeCache(dir, "localize/en.yaml", []byte("welcome: hello")))
+	cfg := &Config{
+		AcceptLanguages: []language.Tag{language.English},
+		RootPath:        "localize",
+		Loader: &StaleLoader{
+			Loader: LoaderFunc(func(path string) ([]byte, error) { return nil, errors.New("timeout") }),
+			Dir:    dir,
+		},
+	}
+	assert.NotPanics(t, func() { NewMiddleware(cfg) })
+	assert.Equal(t, 1, cfg.Stats().Languages["en"].Total)
+}
@@ -0,0 +1,55 @@
+package echoi18n
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// StartupError reports the message files and namespaces that failed to load
+// when creating the middleware.
+type StartupError struct {
+	Diagnostics Diagnostics // Error diagnostics of the catalog load.
+}
+
+// Error lists the failures with the file path and language they concern.
+func (e *StartupError) Error() string {
+	failures := make([]string, len(e.Diagnostics))
+	for i, diagnostic := range e.Diagnostics {
+		failures[i] = diagnostic.describe()
+	}
+	return fmt.Sprintf("i18n.NewMiddleware error: %s", strings.Join(failures, "; "))
+}
+
+// Unwrap returns the underlying errors of the failures, so errors.Is and
+// errors.As match the Loader errors, e.g. os.ErrNotExist.
+func (e *StartupError) Unwrap() []error {
+	errs := make([]error, len(e.Diagnostics))
+	for i, diagnostic := range e.Diagnostics {
+		errs[i] = diagnostic
+	}
+	return errs
+}
+
+// describe returns the diagnostic message prefixed with its file and language.
+func (d Diagnostic) describe() string {
+	switch {
+	case d.File != "" && d.Lang != "":
+		return fmt.Sprintf("%s (%s): %s", d.File, d.Lang, d.Message)
+	case d.File != "":
+		return fmt.Sprintf("%s: %s", d.File, d.Message)
+	case d.Lang != "":
+		return fmt.Sprintf("%s: %s", d.Lang, d.Message)
+	}
+	return d.Message
+}
+
+// NewMiddlewareWithError creates the middleware of NewMiddleware, returning
+// an error instead of panicking when the catalog cannot be served, so that
+// libraries embedding the middleware decide how to handle misconfiguration.
+// Catalog load failures are returned as a *StartupError listing every
+// failing message file.
+func NewMiddlewareWithError(config ...*Config) (echo.MiddlewareFunc, error) {
+	return newMiddleware(config...)
+}
@@ -0,0 +1,53 @@
+package echoi18n
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// TestNewMiddlewareWithError tests reporting startup failures as errors.
+func TestNewMiddlewareWithError(t *testing.T) {
+	t.Parallel()
+	loader := LoaderFunc(func(path string) ([]byte, error) {
+		if path == "example/localize/zh.yaml" {
+			return nil, os.ErrNotExist
+		}
+		return []byte("welcome: [broken"), nil
+	})
+	cfg := &Config{Loader: loader}
+
+	middleware, err := NewMiddlewareWithError(cfg)
+	assert.Nil(t, middleware)
+	var startup *StartupError
+	if assert.True(t, errors.As(err, &startup)) {
+		assert.Len(t, startup.Diagnostics, 2)
+	}
+	assert.Contains(t, err.Error(), "example/localize/zh.yaml (zh): file does not exist")
+	assert.Contains(t, err.Error(), "example/localize/en.yaml (en): yaml: ")
+	assert.ErrorIs(t, err, os.ErrNotExist)
+	assert.PanicsWithError(t, err.Error(), func() { NewMiddleware(cfg) })
+
+	loader = LoaderFunc(func(path string) ([]byte, error) {
+		return []byte("welcome: Welcome"), nil
+	})
+	cfg.Loader = loader
+	middleware, err = NewMiddlewareWithError(cfg)
+	assert.NoError(t, err)
+	assert.Empty(t, cfg.Diagnostics().Filter(SeverityWarning))
+
+	app := echo.New()
+	app.Use(middleware)
+	app.GET("/", func(c echo.Context) error {
+		return LocalizedString(c, http.StatusOK, "welcome")
+	})
+	resp, err := makeRequest(language.English, "", app)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.NoError(t, cfg.Close())
+}
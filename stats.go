@@ -0,0 +1,140 @@
+package echoi18n
+
+import (
+	"sort"
+	"unsafe"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// Stats is the translation report of the loaded catalog.
+type Stats struct {
+	DefaultLanguage string                    `json:"defaultLanguage"`
+	Languages       map[string]LanguageStats  `json:"languages"`
+	Memory          MemoryReport              `json:"memory"`
+	Diagnostics     Diagnostics               `json:"diagnostics,omitempty"`
+	Demand          map[string]LanguageDemand `json:"demand,omitempty"`
+	DetectTimeouts  int64                     `json:"detectTimeouts,omitempty"`
+}
+
+// MemoryReport is the approximate memory used by the catalog, to decide
+// between eager loading, lazy templates and namespace sharding.
+type MemoryReport struct {
+	Languages  map[string]MemoryStats            `json:"languages"`            // Main catalog by language.
+	Namespaces map[string]map[string]MemoryStats `json:"namespaces,omitempty"` // Loaded shards by namespace and language.
+}
+
+// MemoryStats is the approximate memory used by the messages of a language.
+type MemoryStats struct {
+	Messages  int `json:"messages"`  // Loaded messages.
+	Templates int `json:"templates"` // Plural forms executed as templates.
+	Bytes     int `json:"bytes"`     // Approximate size of the messages and their texts.
+}
+
+// LanguageStats is the translation completeness of a single language measured
+// against the default language messages.
+type LanguageStats struct {
+	Total      int      `json:"total"`                // Messages in the default language.
+	Translated int      `json:"translated"`           // Up-to-date translations.
+	Missing    []string `json:"missing,omitempty"`    // IDs without translation.
+	Outdated   []string `json:"outdated,omitempty"`   // IDs whose source changed since translation.
+	Mismatched []string `json:"mismatched,omitempty"` // IDs whose placeholders differ from the source.
+	Altered    []string `json:"altered,omitempty"`    // IDs that altered a protected term of the source.
+}
+
+// isOutdated reports whether a translation was made from a different version
+// of the source message than the current one, based on its recorded hash.
+func isOutdated(source, translation *i18n.Message) bool {
+	return translation.Hash != "" && translation.Hash != messageHash(source)
+}
+
+// indexMessages returns the messages keyed by ID.
+func indexMessages(messages []*i18n.Message) map[string]*i18n.Message {
+	index := make(map[string]*i18n.Message, len(messages))
+	for _, m := range messages {
+		index[m.ID] = m
+	}
+	return index
+}
+
+// messageMemory estimates the memory used by messages.
+func (c *Config) messageMemory(messages []*i18n.Message) MemoryStats {
+	stats := MemoryStats{Messages: len(messages)}
+	for _, m := range messages {
+		stats.Bytes += int(unsafe.Sizeof(*m)) + len(m.ID) + len(m.Hash) + len(m.Description) + len(m.LeftDelim) + len(m.RightDelim)
+		for _, src := range pluralForms(m) {
+			stats.Bytes += len(src)
+			if !c.parser.isRaw(src, m.LeftDelim) {
+				stats.Templates++
+			}
+		}
+	}
+	return stats
+}
+
+// memory returns the memory report of the catalog and the loaded shards.
+func (c *Config) memory() MemoryReport {
+	report := MemoryReport{Languages: make(map[string]MemoryStats, len(c.messages))}
+	for lang, messages := range c.messages {
+		report.Languages[lang] = c.messageMemory(messages)
+	}
+	for _, sh := range c.shards.all() {
+		if report.Namespaces == nil {
+			report.Namespaces = map[string]map[string]MemoryStats{}
+		}
+		report.Namespaces[sh.namespace] = sh.memory
+	}
+	return report
+}
+
+// Stats returns the completeness report of every loaded language, flagging
+// missing and outdated translations, the memory used by the catalog, the
+// load diagnostics, the languages requested with AcceptAnalytics and the
+// language detections past DetectTimeout.
+func (c *Config) Stats() Stats {
+	c = c.active()
+	defaultLang := c.DefaultLanguage.String()
+	sources := make([]*i18n.Message, 0, len(c.messages[defaultLang]))
+	for _, m := range c.messages[defaultLang] {
+		if !isVariantID(m.ID) {
+			sources = append(sources, m)
+		}
+	}
+	stats := Stats{
+		DefaultLanguage: defaultLang,
+		Languages:       make(map[string]LanguageStats, len(c.messages)),
+	}
+
+	for lang, messages := range c.messages {
+		translations := indexMessages(messages)
+		langStats := LanguageStats{Total: len(sources)}
+		for _, source := range sources {
+			translation, ok := translations[source.ID]
+			switch {
+			case !ok:
+				langStats.Missing = append(langStats.Missing, source.ID)
+			case lang != defaultLang && isOutdated(source, translation):
+				langStats.Outdated = append(langStats.Outdated, source.ID)
+			default:
+				langStats.Translated++
+			}
+		}
+		if lang != defaultLang {
+			langStats.Mismatched = c.placeholderMismatches(lang)
+			for id := range c.alteredTerms(lang) {
+				langStats.Altered = append(langStats.Altered, id)
+			}
+		}
+		sort.Strings(langStats.Missing)
+		sort.Strings(langStats.Outdated)
+		sort.Strings(langStats.Altered)
+		stats.Languages[lang] = langStats
+	}
+	stats.Memory = c.memory()
+	stats.Diagnostics = c.Diagnostics()
+	stats.DetectTimeouts = c.root().detectTimeouts.Load()
+	if demand := c.root().demand; demand != nil && c.AcceptAnalytics {
+		stats.Demand = demand.report(c.acceptMatcher().supported)
+	}
+	return stats
+}
@@ -0,0 +1,51 @@
+package echoi18n
+
+import (
+	"os"
+	"testing"
+	"unsafe"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// TestConfig_Stats tests reporting missing and outdated translations.
+func TestConfig_Stats(t *testing.T) {
+	t.Parallel()
+	cfg := &Config{RootPath: "testdata/goi18n", FilePrefix: "active."}
+	NewMiddleware(cfg)
+
+	stats := cfg.Stats()
+	assert.Equal(t, "en", stats.DefaultLanguage)
+	assert.Equal(t, map[string]LanguageStats{
+		"en": {Total: 3, Translated: 3},
+		"zh": {Total: 3, Translated: 1, Missing: []string{"welcomeWithName"}, Outdated: []string{"goodbye"}},
+	}, stats.Languages)
+}
+
+// TestConfig_Stats_memory tests the memory report of the catalog and shards.
+func TestConfig_Stats_memory(t *testing.T) {
+	t.Parallel()
+	cfg := &Config{
+		AcceptLanguages: []language.Tag{language.English},
+		Bundles:         []Bundle{{"en": {{ID: "welcome", Other: "hello"}, {ID: "greet", Other: "hi {{.name}}"}}}},
+		Loader: LoaderFunc(func(path string) ([]byte, error) {
+			if path == "example/localize/checkout/en.yaml" {
+				return []byte("pay: Pay now"), nil
+			}
+			return nil, os.ErrNotExist
+		}),
+		Namespaces: []string{"checkout"},
+	}
+	NewMiddleware(cfg)
+	assert.NoError(t, cfg.LoadNamespace("checkout"))
+
+	memory := cfg.Stats().Memory
+	assert.Equal(t, 2, memory.Languages["en"].Messages)
+	assert.Equal(t, 1, memory.Languages["en"].Templates)
+	size := 2*int(unsafe.Sizeof(i18n.Message{})) + len("welcome"+"hello"+"greet"+"hi {{.name}}")
+	assert.Equal(t, size, memory.Languages["en"].Bytes)
+	shardSize := int(unsafe.Sizeof(i18n.Message{})) + len("pay"+"Pay now")
+	assert.Equal(t, map[string]map[string]MemoryStats{"checkout": {"en": {Messages: 1, Bytes: shardSize}}}, memory.Namespaces)
+}
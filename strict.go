@@ -0,0 +1,27 @@
+package echoi18n
+
+import "github.com/labstack/echo/v4"
+
+// TestingT is the part of testing.TB used by the strict test mode, enabled
+// by passing the test to the middleware of a handler test:
+//
+//	app.Use(echoi18n.NewMiddleware(&echoi18n.Config{Strict: t}))
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// reportStrict fails the test of the strict mode on a Localize error, so a
+// translation gap hit by a handler test turns the build red even if the
+// handler falls back.
+func (c *Config) reportStrict(ctx echo.Context, err error) {
+	if c.Strict == nil || err == nil {
+		return
+	}
+	c.Strict.Helper()
+	if req := ctx.Request(); req != nil {
+		c.Strict.Errorf("%v (language %q, %s %s)", err, c.language(ctx), req.Method, req.URL.Path)
+		return
+	}
+	c.Strict.Errorf("%v (language %q)", err, c.language(ctx))
+}
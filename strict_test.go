@@ -0,0 +1,46 @@
+package echoi18n
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// recordingT is a TestingT recording the reported failures.
+type recordingT struct {
+	mu     sync.Mutex
+	errors []string
+}
+
+// Helper does nothing.
+func (r *recordingT) Helper() {}
+
+// Errorf records a failure.
+func (r *recordingT) Errorf(format string, args ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+// TestConfig_Strict tests failing the test on missing translations without panicking.
+func TestConfig_Strict(t *testing.T) {
+	t.Parallel()
+	strict := &recordingT{}
+	app := echo.New()
+	app.Use(NewMiddleware(&Config{Strict: strict}))
+	app.GET("/", func(c echo.Context) error {
+		return c.String(http.StatusOK, MustLocalize(c, "welcome")+" "+MustLocalize(c, "missing"))
+	})
+
+	got, err := makeRequest(language.Chinese, "", app)
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(got.Body)
+	assert.Equal(t, "你好 missing", string(body))
+	assert.Equal(t, []string{`i18n.Localize error: message "missing" not found in language "zh" (language "zh", GET /)`}, strict.errors)
+}
@@ -0,0 +1,34 @@
+package echoi18n
+
+import (
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// CDN purge headers set when Config.SurrogateKeys is enabled.
+const (
+	HeaderSurrogateKey = "Surrogate-Key"
+	HeaderCacheTag     = "Cache-Tag"
+)
+
+// surrogateKeys returns the purge keys of a response in lang: the locale and
+// the catalog version, so translation updates can purge exactly their pages.
+func (c *Config) surrogateKeys(lang string) []string {
+	return []string{"i18n-" + lang, "i18n-catalog-" + c.Version()}
+}
+
+// setSurrogateKeys adds the purge keys of the negotiated language to the
+// Surrogate-Key (space-separated) and Cache-Tag (comma-separated) headers,
+// keeping the keys set by the application.
+func (c *Config) setSurrogateKeys(ctx echo.Context) {
+	keys := c.surrogateKeys(c.language(ctx))
+	header := ctx.Response().Header()
+	for name, separator := range map[string]string{HeaderSurrogateKey: " ", HeaderCacheTag: ","} {
+		values := keys
+		if existing := header.Get(name); existing != "" {
+			values = append([]string{existing}, keys...)
+		}
+		header.Set(name, strings.Join(values, separator))
+	}
+}
@@ -0,0 +1,32 @@
+package echoi18n
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// TestSurrogateKeys tests the CDN purge headers of localized responses.
+func TestSurrogateKeys(t *testing.T) {
+	t.Parallel()
+	cfg := &Config{SurrogateKeys: true}
+	app := echo.New()
+	app.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Response().Header().Set(HeaderSurrogateKey, "home")
+			return next(c)
+		}
+	})
+	app.Use(NewMiddleware(cfg))
+	app.GET("/", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	got, err := makeRequest(language.Chinese, "", app)
+	assert.NoError(t, err)
+	assert.Equal(t, "home i18n-zh i18n-catalog-"+cfg.Version(), got.Header.Get(HeaderSurrogateKey))
+	assert.Equal(t, "i18n-zh,i18n-catalog-"+cfg.Version(), got.Header.Get(HeaderCacheTag))
+}
@@ -0,0 +1,67 @@
+package echoi18n
+
+import (
+	"errors"
+	"fmt"
+	iofs "io/fs"
+	"path"
+
+	"github.com/labstack/echo/v4"
+	"golang.org/x/text/language"
+)
+
+// templateCandidates returns the template paths to try for a language, from
+// the most specific locale directory down to the shared root directory.
+func templateCandidates(root, name, lang string) []string {
+	var candidates []string
+	seen := map[string]bool{}
+	add := func(dir string) {
+		if !seen[dir] {
+			seen[dir] = true
+			candidates = append(candidates, path.Join(root, dir, name))
+		}
+	}
+	if tag, err := language.Parse(lang); err == nil {
+		for t := tag; t != language.Und; t = t.Parent() {
+			add(t.String())
+		}
+		if base, conf := tag.Base(); conf != language.No {
+			add(base.String())
+		}
+	}
+	add("")
+	return candidates
+}
+
+// ResolveTemplate returns the path of the template file that best matches the
+// negotiated language, e.g. root/zh-TW/name, root/zh/name and finally root/name.
+// Files are looked up through the configured Loader.
+func ResolveTemplate(c echo.Context, root, name string) (string, error) {
+	filepath, _, err := LoadTemplate(c, root, name)
+	return filepath, err
+}
+
+// LoadTemplate loads the template file that best matches the negotiated
+// language. Returns the resolved path and the file content. The name must be
+// a valid fs path, so that it cannot leave root; only missing files fall
+// through to the next candidate, other Loader errors are returned.
+func LoadTemplate(c echo.Context, root, name string) (string, []byte, error) {
+	appCfg, err := getConfig(c)
+	if err != nil {
+		return "", nil, fmt.Errorf("i18n.LoadTemplate error: %v", err)
+	}
+	if !iofs.ValidPath(name) || name == "." {
+		return "", nil, fmt.Errorf("i18n.LoadTemplate error: invalid template name %q", name)
+	}
+
+	for _, filepath := range templateCandidates(root, name, appCfg.language(c)) {
+		buf, err := appCfg.Loader.LoadMessage(filepath)
+		if err == nil {
+			return filepath, buf, nil
+		}
+		if !errors.Is(err, iofs.ErrNotExist) {
+			return "", nil, fmt.Errorf("i18n.LoadTemplate error: %w", err)
+		}
+	}
+	return "", nil, fmt.Errorf("i18n.LoadTemplate error: template %q not found in %q", name, root)
+}
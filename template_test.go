@@ -0,0 +1,106 @@
+package echoi18n
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// templateFiles is an in-memory set of message and template files.
+var templateFiles = map[string]string{
+	"example/localize/en.yaml": "welcome: hello",
+	"example/localize/zh.yaml": "welcome: 你好",
+	"templates/zh/home.html":   "zh home",
+	"templates/home.html":      "home",
+}
+
+// mapLoader loads files from the templateFiles map.
+var mapLoader = LoaderFunc(func(path string) ([]byte, error) {
+	if content, ok := templateFiles[path]; ok {
+		return []byte(content), nil
+	}
+	return nil, os.ErrNotExist
+})
+
+// TestLoadTemplate tests resolving templates from per-locale directories.
+func TestLoadTemplate(t *testing.T) {
+	t.Parallel()
+	app := echo.New()
+	app.Use(NewMiddleware(&Config{Loader: mapLoader, RootPath: "example/localize"}))
+	app.GET("/:name", func(c echo.Context) error {
+		filepath, content, err := LoadTemplate(c, "templates", c.Param("name"))
+		if err != nil {
+			return c.String(http.StatusNotFound, err.Error())
+		}
+		return c.String(http.StatusOK, filepath+": "+string(content))
+	})
+
+	tests := []struct {
+		name string
+		lang language.Tag
+		url  string
+		want string
+	}{
+		{"locale directory", language.Chinese, "home.html", "templates/zh/home.html: zh home"},
+		{"root fallback", language.English, "home.html", "templates/home.html: home"},
+		{"not found", language.English, "missing.html", `i18n.LoadTemplate error: template "missing.html" not found in "templates"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := makeRequest(tt.lang, tt.url, app)
+			assert.NoError(t, err)
+			body, err := io.ReadAll(got.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(body))
+		})
+	}
+}
+
+// TestLoadTemplate_errors tests rejecting names leaving the template root and
+// returning the Loader errors other than missing files.
+func TestLoadTemplate_errors(t *testing.T) {
+	t.Parallel()
+	middleware := NewMiddleware(&Config{
+		RootPath: "example/localize",
+		Loader: LoaderFunc(func(path string) ([]byte, error) {
+			if path == "templates/locked.html" {
+				return nil, os.ErrPermission
+			}
+			return mapLoader(path)
+		}),
+	})
+
+	tests := []struct {
+		name     string
+		template string
+		err      string
+	}{
+		{"parent directory", "../secret.html", `i18n.LoadTemplate error: invalid template name "../secret.html"`},
+		{"absolute", "/etc/passwd", `i18n.LoadTemplate error: invalid template name "/etc/passwd"`},
+		{"loader error", "locked.html", "i18n.LoadTemplate error: permission denied"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+			var err error
+			assert.NoError(t, middleware(func(c echo.Context) error {
+				_, _, err = LoadTemplate(c, "templates", tt.template)
+				return nil
+			})(c))
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+// Test_templateCandidates tests the template lookup order.
+func Test_templateCandidates(t *testing.T) {
+	assert.Equal(t, []string{"t/zh-TW/a.html", "t/zh-Hant/a.html", "t/zh/a.html", "t/a.html"}, templateCandidates("t", "a.html", "zh-TW"))
+	assert.Equal(t, []string{"t/a.html"}, templateCandidates("t", "a.html", "!"))
+}
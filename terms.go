@@ -0,0 +1,95 @@
+package echoi18n
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+)
+
+// defaultTermAltered logs translations that altered a protected term.
+func defaultTermAltered(lang, id, term string) {
+	log.Printf("i18n: message %q in language %q alters protected term %q", id, lang, term)
+}
+
+// alteredTerms returns the protected terms of the default language messages
+// that the translations in lang do not keep verbatim, keyed by message ID.
+func (c *Config) alteredTerms(lang string) map[string][]string {
+	translations := indexMessages(c.messages[lang])
+	altered := map[string][]string{}
+	for _, source := range c.messages[c.DefaultLanguage.String()] {
+		translation, ok := translations[source.ID]
+		if !ok {
+			continue
+		}
+		for _, term := range c.ProtectedTerms {
+			if !strings.Contains(source.Other, term) {
+				continue
+			}
+			for _, form := range pluralForms(translation) {
+				if !strings.Contains(form, term) {
+					altered[source.ID] = append(altered[source.ID], term)
+					break
+				}
+			}
+		}
+	}
+	for _, terms := range altered {
+		sort.Strings(terms)
+	}
+	return altered
+}
+
+// checkTerms reports the translations that altered a protected term as
+// warnings and to TermAltered.
+func (c *Config) checkTerms() Diagnostics {
+	if len(c.ProtectedTerms) == 0 {
+		return nil
+	}
+	var diagnostics Diagnostics
+	for _, lang := range c.AcceptLanguages {
+		if lang == c.DefaultLanguage {
+			continue
+		}
+		altered := c.alteredTerms(lang.String())
+		ids := make([]string, 0, len(altered))
+		for id := range altered {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			for _, term := range altered[id] {
+				c.TermAltered(lang.String(), id, term)
+				diagnostics = append(diagnostics, Diagnostic{
+					Severity:  SeverityWarning,
+					Lang:      lang.String(),
+					MessageID: id,
+					Message:   fmt.Sprintf("message %q in language %q alters protected term %q", id, lang, term),
+				})
+			}
+		}
+	}
+	return diagnostics
+}
+
+// withTerms adds the protected terms missing from map template data, so
+// messages can reference them as template variables.
+func (c *Config) withTerms(data interface{}) interface{} {
+	if data == nil {
+		terms := make(map[string]interface{}, len(c.ProtectedTerms))
+		for name, term := range c.ProtectedTerms {
+			terms[name] = term
+		}
+		return terms
+	}
+	mapped, ok := mapTemplateData(data, func(_ string, value interface{}) interface{} { return value }).(map[string]interface{})
+	if !ok {
+		return data
+	}
+	for name, term := range c.ProtectedTerms {
+		if _, ok := mapped[name]; !ok {
+			mapped[name] = term
+		}
+	}
+	return mapped
+}
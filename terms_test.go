@@ -0,0 +1,72 @@
+package echoi18n
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// termFiles are the message files of the protected term tests.
+var termFiles = map[string]string{
+	"localize/en.yaml": "welcome: Welcome to Acme Cloud\nabout: \"About {{.Brand}}\"\nsignIn: Sign in to Acme Cloud",
+	"localize/zh.yaml": "welcome: 欢迎使用 Acme 云\nabout: \"关于 {{.Brand}}\"\nsignIn: 登录 Acme Cloud",
+}
+
+// TestConfig_checkTerms tests warning about translations altering protected terms.
+func TestConfig_checkTerms(t *testing.T) {
+	t.Parallel()
+	var warnings [][3]string
+	cfg := &Config{
+		Loader: LoaderFunc(func(path string) ([]byte, error) {
+			if content, ok := termFiles[path]; ok {
+				return []byte(content), nil
+			}
+			return nil, os.ErrNotExist
+		}),
+		RootPath:       "localize",
+		ProtectedTerms: map[string]string{"Brand": "Acme Cloud"},
+		InjectTerms:    true,
+		TermAltered: func(lang, id, term string) {
+			warnings = append(warnings, [3]string{lang, id, term})
+		},
+	}
+	app := echo.New()
+	app.Use(NewMiddleware(cfg))
+	app.GET("/", func(c echo.Context) error {
+		return c.String(http.StatusOK, MustLocalize(c, "about"))
+	})
+	app.GET("/data", func(c echo.Context) error {
+		return c.String(http.StatusOK, MustLocalize(c, &i18n.LocalizeConfig{
+			MessageID:    "about",
+			TemplateData: map[string]string{"Brand": "Acme"},
+		}))
+	})
+
+	assert.Equal(t, [][3]string{{"zh", "welcome", "Acme Cloud"}}, warnings)
+	assert.Equal(t, []string{"welcome"}, cfg.Stats().Languages["zh"].Altered)
+
+	tests := []struct {
+		name string
+		lang language.Tag
+		url  string
+		want string
+	}{
+		{"injected", language.Chinese, "", "关于 Acme Cloud"},
+		{"injected default language", language.English, "", "About Acme Cloud"},
+		{"template data wins", language.English, "data", "About Acme"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := makeRequest(tt.lang, tt.url, app)
+			assert.NoError(t, err)
+			body, _ := io.ReadAll(got.Body)
+			assert.Equal(t, tt.want, string(body))
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package echoi18n
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/labstack/echo/v4"
+)
+
+// HeaderTrace is the response header listing the steps of the resolution
+// trace of a request, one value per step.
+const HeaderTrace = "X-I18n-Trace"
+
+// traceKey is the key of the resolution trace of the request in the Echo Context.
+const traceKey = "echoi18n.trace"
+
+// resolutionTrace records how the language of a request was resolved: the
+// detectors tried, the matcher decision and the message fallbacks used. Only
+// the first negotiation of the request is recorded, the later ones repeat it.
+type resolutionTrace struct {
+	mu         sync.Mutex
+	steps      []string
+	negotiated bool
+}
+
+// addf records a step. Callers on the hot path check the trace is not nil
+// first, as the arguments are allocated even if it is.
+func (t *resolutionTrace) addf(format string, args ...interface{}) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.steps = append(t.steps, fmt.Sprintf(format, args...))
+}
+
+// resolved records the result of a resolver of the default LangHandler.
+func (t *resolutionTrace) resolved(resolver LanguageResolver, lang string, ok bool) {
+	if !ok {
+		t.addf("resolver %s: none", resolverName(resolver))
+		return
+	}
+	t.addf("resolver %s: %q", resolverName(resolver), lang)
+}
+
+// done marks the first negotiation of the request as recorded. It does
+// nothing on a nil trace.
+func (t *resolutionTrace) done() {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.negotiated = true
+}
+
+// traceOf returns the resolution trace of the request, nil if it is not traced.
+func traceOf(ctx echo.Context) *resolutionTrace {
+	if ctx == nil {
+		return nil
+	}
+	trace, _ := ctx.Get(traceKey).(*resolutionTrace)
+	return trace
+}
+
+// detectionTrace returns the resolution trace of the request while its first
+// negotiation is recorded, nil otherwise.
+func detectionTrace(ctx echo.Context) *resolutionTrace {
+	trace := traceOf(ctx)
+	if trace == nil {
+		return nil
+	}
+	trace.mu.Lock()
+	defer trace.mu.Unlock()
+	if trace.negotiated {
+		return nil
+	}
+	return trace
+}
+
+// tracing reports whether the request asks for its resolution trace with the
+// TraceParam query parameter and TraceAccess allows it.
+func (c *Config) tracing(ctx echo.Context) bool {
+	if c.TraceParam == "" || queryParam(ctx, c.TraceParam) == "" {
+		return false
+	}
+	return c.TraceAccess == nil || c.TraceAccess(ctx)
+}
+
+// setTraceHeaders sets the steps of the resolution trace of the request in
+// the X-I18n-Trace response header, ending with the language served.
+func (c *Config) setTraceHeaders(ctx echo.Context) {
+	trace := traceOf(ctx)
+	if trace == nil {
+		return
+	}
+	lang, source := c.negotiate(ctx)
+	trace.mu.Lock()
+	defer trace.mu.Unlock()
+	header := ctx.Response().Header()
+	for _, step := range trace.steps {
+		header.Add(HeaderTrace, step)
+	}
+	header.Add(HeaderTrace, fmt.Sprintf("served: %s (%s)", lang, source))
+}
@@ -0,0 +1,82 @@
+package echoi18n
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// TestConfig_TraceParam tests attaching the resolution trace of a request to
+// its response.
+func TestConfig_TraceParam(t *testing.T) {
+	t.Parallel()
+	files := map[string]string{
+		"localize/en.yaml":    "welcome: Welcome\nbye: Bye",
+		"localize/zh.yaml":    "welcome: 你好\nbye: 再见",
+		"localize/zh-TW.yaml": "welcome: 歡迎",
+	}
+	app := echo.New()
+	app.Use(NewMiddleware(&Config{
+		AcceptLanguages: []language.Tag{language.English, language.Chinese, language.Make("zh-TW")},
+		RootPath:        "localize",
+		Loader: LoaderFunc(func(path string) ([]byte, error) {
+			return []byte(files[path]), nil
+		}),
+		FallbackChain: ParentFallback,
+		TraceParam:    "i18n_trace",
+		TraceAccess: func(c echo.Context) bool {
+			return c.QueryParam("support") != "denied"
+		},
+	}))
+	app.GET("/:id", func(c echo.Context) error {
+		message, err := Localize(c, c.Param("id"))
+		if err != nil {
+			return c.NoContent(http.StatusNotFound)
+		}
+		return c.String(http.StatusOK, message)
+	})
+
+	tests := []struct {
+		name string
+		lang language.Tag
+		url  string
+		want []string
+	}{
+		{"not traced", language.Chinese, "welcome", nil},
+		{"denied", language.Und, "welcome?i18n_trace=1&support=denied", nil},
+		{"fallback", language.Make("zh-TW"), "bye?i18n_trace=1", []string{
+			"resolver path: none",
+			"resolver query: none",
+			"resolver cookie: none",
+			`resolver header: "zh-TW"`,
+			`detected: "zh-TW" (header)`,
+			"match: zh-TW is supported",
+			`fallback: "bye" missing in zh-TW, served in zh`,
+			"served: zh-TW (header)",
+		}},
+		{"closest", language.Und, "welcome?i18n_trace=1&lang=en-GB", []string{
+			"resolver path: none",
+			`resolver query: "en-GB"`,
+			`detected: "en-GB" (query)`,
+			`match: en is the closest supported language to "en-GB"`,
+			"served: en (query)",
+		}},
+		{"unsupported", language.Und, "welcome?i18n_trace=1&lang=fr", []string{
+			"resolver path: none",
+			`resolver query: "fr"`,
+			`detected: "fr" (query)`,
+			`match: no supported language for "fr"`,
+			"served: en (fallback)",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := makeRequest(tt.lang, tt.url, app)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got.Header.Values(HeaderTrace))
+		})
+	}
+}
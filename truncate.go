@@ -0,0 +1,102 @@
+package echoi18n
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ellipsisMessageID is the catalog message overriding the built-in ellipsis.
+const ellipsisMessageID = "echoi18n.ellipsis"
+
+// ellipses are the built-in ellipsis characters that differ from "…".
+var ellipses = map[string]string{
+	"zh": "……",
+}
+
+// graphemes splits s into user-perceived characters, keeping combining marks,
+// variation selectors, emoji modifiers, ZWJ sequences and regional indicator
+// pairs attached to their base character.
+func graphemes(s string) []string {
+	var clusters []string
+	start := 0
+	var prev rune
+	regionalIndicators := 0
+	for i, r := range s {
+		if i > 0 && !extendsCluster(prev, r, regionalIndicators) {
+			clusters = append(clusters, s[start:i])
+			start = i
+			regionalIndicators = 0
+		}
+		if isRegionalIndicator(r) {
+			regionalIndicators++
+		}
+		prev = r
+	}
+	if start < len(s) {
+		clusters = append(clusters, s[start:])
+	}
+	return clusters
+}
+
+// extendsCluster reports whether r continues the cluster ending with prev.
+func extendsCluster(prev, r rune, regionalIndicators int) bool {
+	switch {
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc):
+		return true
+	case r == '\u200d' || prev == '\u200d':
+		return true
+	case r >= 0xfe00 && r <= 0xfe0f, r >= 0x1f3fb && r <= 0x1f3ff, r >= 0xe0020 && r <= 0xe007f:
+		return true
+	case isRegionalIndicator(prev) && isRegionalIndicator(r):
+		return regionalIndicators%2 == 1
+	case prev == '\r' && r == '\n':
+		return true
+	}
+	return false
+}
+
+// isRegionalIndicator reports whether r is a flag emoji letter.
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1f1e6 && r <= 0x1f1ff
+}
+
+// Ellipsis returns the ellipsis of the negotiated language, which may be
+// overridden per language with the "echoi18n.ellipsis" message. The override
+// is optional: its absence is not reported to Strict, the budgets or audits.
+func Ellipsis(c echo.Context) string {
+	if appCfg, err := getConfig(c); err == nil {
+		if m, ok := appCfg.index[appCfg.language(c)][ellipsisMessageID]; ok && m.Other != "" {
+			return m.Other
+		}
+	}
+	base, _ := requestLanguage(c).Base()
+	if ellipsis, ok := ellipses[base.String()]; ok {
+		return ellipsis
+	}
+	return "…"
+}
+
+// Truncate shortens s to at most n grapheme clusters, including the localized
+// ellipsis appended when s is cut, so it never splits a user-perceived character.
+func Truncate(c echo.Context, s string, n int) string {
+	if n <= 0 {
+		return ""
+	}
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	clusters := graphemes(s)
+	if len(clusters) <= n {
+		return s
+	}
+
+	ellipsis := Ellipsis(c)
+	keep := n - len(graphemes(ellipsis))
+	if keep <= 0 {
+		ellipsis, keep = "", n
+	}
+	return strings.Join(clusters[:keep], "") + ellipsis
+}
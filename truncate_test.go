@@ -0,0 +1,103 @@
+package echoi18n
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// Test_graphemes tests splitting strings into user-perceived characters.
+func Test_graphemes(t *testing.T) {
+	assert.Equal(t, []string{"é", "a"}, graphemes("éa"))
+	assert.Equal(t, []string{"👍🏽", "!"}, graphemes("👍🏽!"))
+	assert.Equal(t, []string{"👩‍💻", "x"}, graphemes("👩‍💻x"))
+	assert.Equal(t, []string{"🇨🇳", "🇺🇸"}, graphemes("🇨🇳🇺🇸"))
+}
+
+// TestTruncate tests grapheme-safe truncation with a localized ellipsis.
+func TestTruncate(t *testing.T) {
+	t.Parallel()
+	app := echo.New()
+	app.Use(NewMiddleware(&Config{}))
+	app.GET("/:n", func(c echo.Context) error {
+		n, _ := strconv.Atoi(c.Param("n"))
+		return c.String(http.StatusOK, Truncate(c, c.QueryParam("s"), n))
+	})
+
+	tests := []struct {
+		name string
+		lang language.Tag
+		url  string
+		want string
+	}{
+		{"short", language.English, "5?s=hello", "hello"},
+		{"english", language.English, "4?s=hello", "hel…"},
+		{"combining marks", language.English, "3?s=e%CC%81e%CC%81e%CC%81e%CC%81", "éé…"},
+		{"chinese", language.Chinese, "4?s=你好世界你好", "你好……"},
+		{"too short for ellipsis", language.Chinese, "1?s=你好", "你"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := makeRequest(tt.lang, tt.url, app)
+			assert.NoError(t, err)
+			body, _ := io.ReadAll(got.Body)
+			assert.Equal(t, tt.want, string(body))
+		})
+	}
+}
+
+// TestEllipsis_strict tests looking up the optional ellipsis override without
+// reporting its absence as a missing translation.
+func TestEllipsis_strict(t *testing.T) {
+	t.Parallel()
+	strict := &recordingT{}
+	app := echo.New()
+	app.Use(NewMiddleware(&Config{
+		Strict:  strict,
+		Bundles: []Bundle{{"en": {{ID: ellipsisMessageID, Other: "..."}}}},
+	}))
+	app.GET("/", func(c echo.Context) error {
+		return c.String(http.StatusOK, Truncate(c, "hello world", 8))
+	})
+
+	tests := []struct {
+		name string
+		lang language.Tag
+		want string
+	}{
+		{"override", language.English, "hello..."},
+		{"built-in", language.Chinese, "hello ……"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := makeRequest(tt.lang, "", app)
+			assert.NoError(t, err)
+			body, _ := io.ReadAll(got.Body)
+			assert.Equal(t, tt.want, string(body))
+		})
+	}
+	assert.Empty(t, strict.errors)
+}
+
+// TestConfig_NormalizeNFC tests normalizing localized messages.
+func TestConfig_NormalizeNFC(t *testing.T) {
+	t.Parallel()
+	app := echo.New()
+	app.Use(NewMiddleware(&Config{
+		NormalizeNFC: true,
+		Bundles:      []Bundle{{"en": {{ID: "cafe", Other: "cafe\u0301"}}}},
+	}))
+	app.GET("/", func(c echo.Context) error {
+		return c.String(http.StatusOK, MustLocalize(c, "cafe"))
+	})
+
+	got, err := makeRequest(language.English, "", app)
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(got.Body)
+	assert.Equal(t, "caf\u00e9", string(body))
+}
@@ -0,0 +1,95 @@
+package echoi18n
+
+import (
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// variantMarker opens and closes the variant name in the ID under which the
+// variant of a message is stored. Message IDs starting with it are reserved:
+// NUL never appears in the message IDs of the files.
+const variantMarker = "\x00"
+
+// variantID returns the ID under which the variant of a message is stored.
+func variantID(id, variant string) string {
+	return variantMarker + variant + variantMarker + id
+}
+
+// isVariantID reports whether the ID belongs to a message variant.
+func isVariantID(id string) bool {
+	return strings.HasPrefix(id, variantMarker)
+}
+
+// baseID returns the ID of the message a variant ID belongs to, or the ID
+// itself if it is not a variant.
+func baseID(id string) string {
+	if !isVariantID(id) {
+		return id
+	}
+	if _, base, ok := strings.Cut(id[len(variantMarker):], variantMarker); ok {
+		return base
+	}
+	return id
+}
+
+// variantMessages returns copies of the messages of a variant catalog,
+// with IDs marking them as variants of the language messages.
+func variantMessages(messages []*i18n.Message, variant string) []*i18n.Message {
+	variants := make([]*i18n.Message, len(messages))
+	for i, m := range messages {
+		message := *m
+		message.ID = variantID(m.ID, variant)
+		variants[i] = &message
+	}
+	return variants
+}
+
+// initVariants indexes the loaded message variants of each language.
+func (c *Config) initVariants() {
+	variants := make(map[string]map[string]bool, len(c.messages))
+	for lang, messages := range c.messages {
+		for _, m := range messages {
+			if !isVariantID(m.ID) {
+				continue
+			}
+			if variants[lang] == nil {
+				variants[lang] = map[string]bool{}
+			}
+			variants[lang][m.ID] = true
+		}
+	}
+	c.variants = variants
+}
+
+// requestVariants returns the variants preferred by the request in order,
+// starting with its register.
+func (c *Config) requestVariants(ctx echo.Context) []string {
+	variants := c.Variants
+	if c.VariantResolver != nil {
+		variants = c.VariantResolver(ctx)
+	}
+	if register := c.register(ctx); register != "" {
+		return append([]string{register}, variants...)
+	}
+	return variants
+}
+
+// withVariant returns a copy of the localize config targeting the first
+// preferred variant of the message available in the language, or the config
+// itself to fall back to the neutral message. Default messages have no variants.
+func (c *Config) withVariant(lang string, variants []string, lc *i18n.LocalizeConfig) *i18n.LocalizeConfig {
+	if lc.DefaultMessage != nil {
+		return lc
+	}
+	for _, variant := range variants {
+		id := variantID(lc.MessageID, variant)
+		if c.variants[lang][id] {
+			localized := *lc
+			localized.MessageID = id
+			return &localized
+		}
+	}
+	return lc
+}
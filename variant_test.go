@@ -0,0 +1,112 @@
+package echoi18n
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// variantFiles are the message files of the variant tests.
+var variantFiles = map[string]string{
+	"localize/en.yaml":             "welcome: Welcome\nstudents: Students\nhelp: Help",
+	"localize/de.yaml":             "welcome: Willkommen\nstudents: Studenten\nhelp: Hilfe",
+	"localize/de-x-inclusive.yaml": "students: Studierende",
+	"localize/de-x-formal.yaml":    "welcome: Willkommen, schön, dass Sie da sind",
+	"localize/de-x-informal.yaml":  "welcome: Willkommen, schön, dass du da bist",
+}
+
+// variantLoader loads files from the variantFiles map.
+var variantLoader = LoaderFunc(func(path string) ([]byte, error) {
+	if content, ok := variantFiles[path]; ok {
+		return []byte(content), nil
+	}
+	return nil, os.ErrNotExist
+})
+
+// newVariantServer creates an Echo server localizing the message in the id parameter.
+func newVariantServer(cfg *Config) *echo.Echo {
+	cfg.AcceptLanguages = []language.Tag{language.German, language.English}
+	cfg.Loader = variantLoader
+	cfg.RootPath = "localize"
+	app := echo.New()
+	app.Use(NewMiddleware(cfg))
+	app.GET("/:id", func(c echo.Context) error {
+		return c.String(http.StatusOK, MustLocalize(c, c.Param("id")))
+	})
+	return app
+}
+
+// TestLocalize_variants tests serving variant catalogs with neutral fallback.
+func TestLocalize_variants(t *testing.T) {
+	t.Parallel()
+	inclusive := newVariantServer(&Config{Variants: []string{"inclusive"}})
+	preference := newVariantServer(&Config{
+		Variants: []string{"inclusive"},
+		VariantResolver: func(c echo.Context) []string {
+			if c.QueryParam("inclusive") != "" {
+				return []string{"inclusive"}
+			}
+			return nil
+		},
+	})
+
+	tests := []struct {
+		name string
+		app  *echo.Echo
+		lang language.Tag
+		url  string
+		want string
+	}{
+		{"variant", inclusive, language.German, "students", "Studierende"},
+		{"neutral fallback", inclusive, language.German, "welcome", "Willkommen"},
+		{"language without variant", inclusive, language.English, "students", "Students"},
+		{"not preferred", preference, language.German, "students", "Studenten"},
+		{"preferred", preference, language.German, "students?inclusive=1", "Studierende"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := makeRequest(tt.lang, tt.url, tt.app)
+			assert.NoError(t, err)
+			body, _ := io.ReadAll(got.Body)
+			assert.Equal(t, tt.want, string(body))
+		})
+	}
+}
+
+// TestConfig_Stats_variants tests that variants are not reported as missing translations.
+func TestConfig_Stats_variants(t *testing.T) {
+	t.Parallel()
+	cfg := &Config{Variants: []string{"inclusive"}, DefaultLanguage: language.German}
+	newVariantServer(cfg)
+	stats := cfg.Stats()
+	assert.Equal(t, 3, stats.Languages["en"].Total)
+	assert.Empty(t, stats.Languages["en"].Missing)
+}
+
+// TestIsVariantID tests recognizing only the IDs of variants, not message IDs
+// containing the variant separators of other formats.
+func TestIsVariantID(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		id       string
+		want     bool
+		wantBase string
+	}{
+		{"message", "welcome", false, "welcome"},
+		{"hash", "section#intro", false, "section#intro"},
+		{"variant", variantID("students", "inclusive"), true, "students"},
+		{"variant of hash", variantID("section#intro", "formal"), true, "section#intro"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isVariantID(tt.id))
+			assert.Equal(t, tt.wantBase, baseID(tt.id))
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package echoi18n
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"sort"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// VersionContextKey is the Echo Context key of the catalog version serving the request.
+const VersionContextKey = "echoi18n.version"
+
+// HeaderCatalogVersion is the conventional response header of the catalog version.
+const HeaderCatalogVersion = "X-I18n-Catalog-Version"
+
+// catalogVersion returns a short content hash of the messages, identical for
+// identical catalogs regardless of the loading order.
+func catalogVersion(messages Bundle) string {
+	langs := make([]string, 0, len(messages))
+	for lang := range messages {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+
+	h := sha1.New()
+	for _, lang := range langs {
+		index := indexMessages(messages[lang])
+		ids := make([]string, 0, len(index))
+		for id := range index {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			m := index[id]
+			h.Write([]byte(strings.Join([]string{lang, id, m.Zero, m.One, m.Two, m.Few, m.Many, m.Other}, "\x00") + "\x00"))
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))[:12]
+}
+
+// stateVersion returns a short hash of a catalog version and the workflow
+// states set by SetState over it.
+func stateVersion(version string, states map[string]map[string]string) string {
+	var entries []string
+	for lang, ids := range states {
+		for id, state := range ids {
+			entries = append(entries, strings.Join([]string{lang, id, state}, "\x00"))
+		}
+	}
+	sort.Strings(entries)
+
+	h := sha1.New()
+	h.Write([]byte(version))
+	for _, entry := range entries {
+		h.Write([]byte("\x00" + entry))
+	}
+	return hex.EncodeToString(h.Sum(nil))[:12]
+}
+
+// Version returns the version of the loaded catalog, a hash of its messages
+// that changes whenever a translation does.
+func (c *Config) Version() string {
+	return c.active().version
+}
+
+// CatalogVersion returns the version of the catalog serving the request, to
+// correlate logs and bug reports with the translations that were live.
+// Returns "" without the middleware.
+func CatalogVersion(c echo.Context) string {
+	version, _ := c.Get(VersionContextKey).(string)
+	return version
+}
@@ -0,0 +1,45 @@
+package echoi18n
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// Test_catalogVersion tests that the version depends on the content only.
+func Test_catalogVersion(t *testing.T) {
+	hello := &i18n.Message{ID: "hello", Other: "Hello"}
+	bye := &i18n.Message{ID: "bye", Other: "Bye"}
+	version := catalogVersion(Bundle{"en": {hello, bye}})
+
+	assert.Len(t, version, 12)
+	assert.Equal(t, version, catalogVersion(Bundle{"en": {bye, hello}}))
+	assert.NotEqual(t, version, catalogVersion(Bundle{"en": {hello, {ID: "bye", Other: "Goodbye"}}}))
+	assert.NotEqual(t, version, catalogVersion(Bundle{"zh": {hello, bye}}))
+}
+
+// TestCatalogVersion tests stamping the catalog version on every request.
+func TestCatalogVersion(t *testing.T) {
+	t.Parallel()
+	cfg := &Config{VersionHeader: HeaderCatalogVersion}
+	app := echo.New()
+	app.Use(NewMiddleware(cfg))
+	app.GET("/", func(c echo.Context) error {
+		return c.String(http.StatusOK, CatalogVersion(c))
+	})
+
+	got, err := makeRequest(language.Und, "", app)
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(got.Body)
+	assert.Equal(t, cfg.Version(), string(body))
+	assert.Equal(t, cfg.Version(), got.Header.Get(HeaderCatalogVersion))
+
+	c := app.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+	assert.Equal(t, "", CatalogVersion(c))
+}
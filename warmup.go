@@ -0,0 +1,40 @@
+package echoi18n
+
+import "fmt"
+
+// warmUp loads the namespace shards, up to MaxShards, creates the localizers
+// of the fallback chain of every supported language in the main catalog and
+// the loaded shards, and compiles the lazy message templates ahead of the
+// first requests. Failures are reported as warnings, as they would only
+// surface on first use otherwise.
+func (c *Config) warmUp() Diagnostics {
+	var diagnostics Diagnostics
+	for i, namespace := range c.Namespaces {
+		if c.MaxShards > 0 && i >= c.MaxShards {
+			break
+		}
+		if _, err := c.shard(namespace); err != nil {
+			diagnostics = append(diagnostics, Diagnostic{Severity: SeverityWarning, Message: fmt.Sprintf("namespace %q: %v", namespace, err)})
+		}
+	}
+	if c.fallbacks != nil {
+		for _, tag := range c.AcceptLanguages {
+			lang := tag.String()
+			if _, err := c.chainLocalizers(c.chains, lang, c.languageLocalizer); err != nil {
+				diagnostics = append(diagnostics, Diagnostic{Severity: SeverityWarning, Lang: lang, Message: err.Error()})
+			}
+			for _, sh := range c.shards.all() {
+				if _, err := c.chainLocalizers(&sh.chains, lang, sh.localizer); err != nil {
+					diagnostics = append(diagnostics, Diagnostic{Severity: SeverityWarning, Lang: lang, Message: fmt.Sprintf("namespace %q: %v", sh.namespace, err)})
+				}
+			}
+		}
+	}
+	if c.LazyTemplates {
+		for _, diagnostic := range c.validateTemplates() {
+			diagnostic.Severity = SeverityWarning
+			diagnostics = append(diagnostics, diagnostic)
+		}
+	}
+	return diagnostics
+}
@@ -0,0 +1,50 @@
+package echoi18n
+
+import (
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestConfig_WarmUp tests loading shards, creating the fallback chain
+// localizers and compiling templates at startup.
+func TestConfig_WarmUp(t *testing.T) {
+	t.Parallel()
+	files := map[string]string{
+		"localize/en.yaml":          "welcome: hello\nshout: \"{{ upper .name }}\"",
+		"localize/zh.yaml":          "welcome: 你好",
+		"localize/checkout/en.yaml": "title: Checkout",
+	}
+	cfg := &Config{
+		Loader: LoaderFunc(func(path string) ([]byte, error) {
+			if content, ok := files[path]; ok {
+				return []byte(content), nil
+			}
+			return nil, os.ErrNotExist
+		}),
+		RootPath:      "localize",
+		Namespaces:    []string{"checkout", "account", "search"},
+		MaxShards:     2,
+		LazyTemplates: true,
+		FallbackChain: ParentFallback,
+		WarmUp:        true,
+	}
+	NewMiddleware(cfg)
+
+	assert.Equal(t, []string{"account", "checkout"}, cfg.LoadedNamespaces())
+	assert.Equal(t, Diagnostics{{
+		Severity:  SeverityWarning,
+		Lang:      "en",
+		MessageID: "shout",
+		Message:   `message "shout" in language "en": template: :1: function "upper" not defined`,
+	}}, cfg.Diagnostics().Filter(SeverityWarning))
+	for _, chains := range []*sync.Map{cfg.active().chains, &cfg.active().shards.all()[1].chains} {
+		chain, ok := chains.Load("zh")
+		assert.True(t, ok)
+		assert.Len(t, chain, 1)
+	}
+	_, ok := cfg.active().parser.compiled.Load(templateKey{"{{ upper .name }}", "", ""})
+	assert.True(t, ok)
+}
@@ -0,0 +1,47 @@
+package echoi18n
+
+import "time"
+
+// watch polls the message files every interval, delayed by up to the
+// ReloadJitter of settings, until stop is closed, swapping in the catalog
+// when its version changes, e.g. while translators edit the files, then
+// closes done. Load errors are logged and the current catalog stays in
+// service.
+func (c *Config) watch(settings *Config, interval time.Duration, stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+	for settings.sleep(interval+settings.jitter(), stop) {
+		changed, err := c.reload(true)
+		if err == nil {
+			err = c.publish(changed)
+		}
+		if err != nil {
+			settings.logf("i18n: reload: %v", err)
+		}
+	}
+}
+
+// stopWatching stops the poller of ReloadInterval or the RefreshInterval of
+// the Layers, if any, waiting for a reload in progress.
+func (c *Config) stopWatching() {
+	root := c.root()
+	root.mu.Lock()
+	stop, done := root.stop, root.watched
+	root.stop, root.watched = nil, nil
+	root.mu.Unlock()
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+}
+
+// Close releases a Config served by NewMiddleware: it stops watching the
+// message files for changes with ReloadInterval or the RefreshInterval of the
+// Layers, waiting for a reload in progress, and removes the Config from the
+// middleware registry, which otherwise counts it against Exclusive Configs
+// and keeps an Exclusive one in memory for the life of the process. Call it
+// once the middleware no longer serves requests.
+func (c *Config) Close() error {
+	c.stopWatching()
+	registry.release(c.root())
+	return nil
+}
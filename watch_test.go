@@ -0,0 +1,73 @@
+package echoi18n
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// TestConfig_ReloadInterval tests swapping in edited message files.
+func TestConfig_ReloadInterval(t *testing.T) {
+	t.Parallel()
+	loader := &reloadLoader{}
+	loader.set("welcome: v1", nil)
+	cfg := &Config{AcceptLanguages: []language.Tag{language.English}, Loader: loader, RootPath: "localize", ReloadInterval: time.Millisecond}
+	NewMiddleware(cfg)
+	defer cfg.Close()
+
+	snapshot := cfg.active()
+	time.Sleep(20 * time.Millisecond)
+	assert.Same(t, snapshot, cfg.active())
+
+	loader.set("welcome: v2", nil)
+	assert.Eventually(t, func() bool {
+		return cfg.active() != snapshot
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, "v2", cfg.active().raw["en"]["welcome"])
+
+	assert.NoError(t, cfg.Close())
+	assert.NoError(t, cfg.Close())
+	snapshot = cfg.active()
+	loader.set("welcome: v3", nil)
+	time.Sleep(20 * time.Millisecond)
+	assert.Same(t, snapshot, cfg.active())
+}
+
+// TestConfig_ReloadInterval_restart tests replacing the poller of a Config
+// passed to NewMiddleware again, and logging failed reloads.
+func TestConfig_ReloadInterval_restart(t *testing.T) {
+	t.Parallel()
+	loader := &reloadLoader{}
+	loader.set("welcome: v1", nil)
+	ticks := make(chan time.Time)
+	var logs bytes.Buffer
+	logger := echo.New().Logger
+	logger.SetOutput(&logs)
+	logger.SetHeader("${level}")
+	cfg := &Config{
+		AcceptLanguages: []language.Tag{language.English},
+		Loader:          loader,
+		RootPath:        "localize",
+		ReloadInterval:  time.Hour,
+		After:           func(time.Duration) <-chan time.Time { return ticks },
+		Logger:          logger,
+	}
+	NewMiddleware(cfg)
+	first := cfg.watched
+	NewMiddleware(cfg)
+	defer cfg.Close()
+	select {
+	case <-first:
+	default:
+		t.Fatal("the first poller is still running")
+	}
+
+	loader.set("welcome: v2", assert.AnError)
+	ticks <- time.Time{}
+	assert.NoError(t, cfg.Close())
+	assert.Contains(t, logs.String(), "ERROR i18n: reload: ")
+}
@@ -0,0 +1,106 @@
+package echoi18n
+
+import (
+	"fmt"
+)
+
+// Translation workflow states, read from the "state" field of a message.
+const (
+	StateDraft    = "draft"
+	StateReviewed = "reviewed"
+	StateApproved = "approved"
+)
+
+// State returns the workflow state of a translation. Messages without a state
+// field are considered approved.
+func (c *Config) State(lang, id string) string {
+	c = c.active()
+	return c.state(lang, c.normalizeID(id))
+}
+
+// state returns the workflow state of a translation of a normalized ID.
+func (c *Config) state(lang, id string) string {
+	if state := c.metadata[lang][id]["state"]; state != "" {
+		return state
+	}
+	return StateApproved
+}
+
+// SetState changes the workflow state of a loaded translation at runtime,
+// publishing a new catalog version. The state overrides the one of the
+// message files across reloads. It fails with ErrReadOnly if the Config is
+// ReadOnly.
+func (c *Config) SetState(lang, id, state string) error {
+	if err := c.writable(); err != nil {
+		return fmt.Errorf("i18n.SetState error: %w", err)
+	}
+	switch state {
+	case StateDraft, StateReviewed, StateApproved:
+	default:
+		return fmt.Errorf("i18n.SetState error: invalid state %q", state)
+	}
+
+	root := c.root()
+	root.mu.Lock()
+	defer root.mu.Unlock()
+	current := root.active()
+	id = current.normalizeID(id)
+	if current.index[lang][id] == nil {
+		return fmt.Errorf("i18n.SetState error: message %q not found in language %q", id, lang)
+	}
+
+	if root.states == nil {
+		root.states = map[string]map[string]string{}
+	}
+	if root.states[lang] == nil {
+		root.states[lang] = map[string]string{}
+	}
+	root.states[lang][id] = state
+	snapshot := current.derive()
+	snapshot.version = catalogVersion(current.messages)
+	snapshot.applyStates(root.states)
+	root.store(snapshot)
+	return nil
+}
+
+// applyStates overlays the workflow states set by SetState on the metadata
+// of the loaded messages, and folds them into the catalog version.
+func (c *Config) applyStates(states map[string]map[string]string) {
+	if len(states) == 0 {
+		return
+	}
+	metadata := make(map[string]map[string]Metadata, len(c.metadata)+len(states))
+	for lang, fields := range c.metadata {
+		metadata[lang] = fields
+	}
+	for lang, ids := range states {
+		overlay := make(map[string]Metadata, len(c.metadata[lang])+len(ids))
+		for id, fields := range c.metadata[lang] {
+			overlay[id] = fields
+		}
+		for id, state := range ids {
+			if c.index[lang][id] == nil {
+				continue
+			}
+			fields := Metadata{"state": state}
+			for k, v := range c.metadata[lang][id] {
+				if k != "state" {
+					fields[k] = v
+				}
+			}
+			overlay[id] = fields
+		}
+		metadata[lang] = overlay
+	}
+	c.metadata = metadata
+	c.version = stateVersion(c.version, states)
+}
+
+// isServable reports whether the translation may be served. With ApprovedOnly
+// set, unapproved translations fall back to the default language.
+func (c *Config) isServable(lang, id string) bool {
+	if !c.ApprovedOnly || lang == c.DefaultLanguage.String() {
+		return true
+	}
+	return c.state(lang, id) == StateApproved
+}
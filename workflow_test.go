@@ -0,0 +1,84 @@
+package echoi18n
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// TestConfig_ApprovedOnly tests serving only approved translations.
+func TestConfig_ApprovedOnly(t *testing.T) {
+	t.Parallel()
+	cfg := &Config{RootPath: "testdata/workflow", ApprovedOnly: true}
+	app := newAdminServer(cfg)
+	app.GET("/:name", func(c echo.Context) error {
+		return c.String(http.StatusOK, MustLocalize(c, c.Param("name")))
+	})
+
+	tests := []struct {
+		name string
+		url  string
+		want string
+	}{
+		{"approved", "welcome", "你好"},
+		{"draft", "checkout.title", "Checkout"},
+		{"reviewed", "checkout.pay", "Pay now"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := makeRequest(language.Chinese, tt.url, app)
+			assert.NoError(t, err)
+			body, _ := io.ReadAll(got.Body)
+			assert.Equal(t, tt.want, string(body))
+		})
+	}
+
+	req := httptest.NewRequest(http.MethodPut, "/admin/state/zh/checkout.pay", strings.NewReader(`{"state": "approved"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	got, err := makeRequest(language.Chinese, "checkout.pay", app)
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(got.Body)
+	assert.Equal(t, "立即付款", string(body))
+}
+
+// TestConfig_SetState tests validating workflow state changes.
+func TestConfig_SetState(t *testing.T) {
+	t.Parallel()
+	cfg := &Config{RootPath: "testdata/workflow"}
+	NewMiddleware(cfg)
+
+	assert.Equal(t, StateDraft, cfg.State("zh", "checkout.title"))
+	assert.Equal(t, StateApproved, cfg.State("en", "welcome"))
+	assert.NoError(t, cfg.SetState("zh", "checkout.title", StateReviewed))
+	assert.Equal(t, StateReviewed, cfg.State("zh", "checkout.title"))
+	assert.EqualError(t, cfg.SetState("zh", "checkout.title", "done"), `i18n.SetState error: invalid state "done"`)
+	assert.EqualError(t, cfg.SetState("zh", "missing", StateDraft), `i18n.SetState error: message "missing" not found in language "zh"`)
+}
+
+// TestConfig_SetState_reload tests publishing state changes as catalog
+// versions and keeping them across reloads.
+func TestConfig_SetState_reload(t *testing.T) {
+	t.Parallel()
+	cfg := &Config{RootPath: "testdata/workflow"}
+	NewMiddleware(cfg)
+	loaded := cfg.Version()
+
+	assert.NoError(t, cfg.SetState("zh", "checkout.title", StateApproved))
+	assert.NotEqual(t, loaded, cfg.Version())
+	assert.Equal(t, []string{loaded, cfg.Version()}, cfg.Versions())
+
+	approved := cfg.Version()
+	assert.NoError(t, cfg.Reload())
+	assert.Equal(t, StateApproved, cfg.State("zh", "checkout.title"))
+	assert.Equal(t, approved, cfg.Version())
+}